@@ -2,8 +2,10 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/line/ostracon/abci/types"
 	tmnet "github.com/line/ostracon/libs/net"
@@ -19,10 +21,36 @@ type GRPCServer struct {
 	server   *grpc.Server
 
 	app types.ABCIApplicationServer
+
+	// nil for the gRPC defaults
+	keepaliveParams *keepalive.ServerParameters
+	keepalivePolicy *keepalive.EnforcementPolicy
+}
+
+// GRPCServerOption sets an optional parameter on the GRPCServer.
+type GRPCServerOption func(*GRPCServer)
+
+// WithKeepalive makes the server ping a connection idle for the given interval,
+// and close it if the ping isn't acknowledged within the given timeout. The
+// clients may ping the server as often as the given interval, even without any
+// active stream if permitWithoutStream is true. By default, the gRPC defaults
+// apply: the server pings an idle connection every 2 hours and expects the
+// clients to ping at most every 5 minutes, while they have an active stream.
+func WithKeepalive(interval, timeout time.Duration, permitWithoutStream bool) GRPCServerOption {
+	return func(s *GRPCServer) {
+		s.keepaliveParams = &keepalive.ServerParameters{
+			Time:    interval,
+			Timeout: timeout,
+		}
+		s.keepalivePolicy = &keepalive.EnforcementPolicy{
+			MinTime:             interval,
+			PermitWithoutStream: permitWithoutStream,
+		}
+	}
 }
 
 // NewGRPCServer returns a new gRPC ABCI server
-func NewGRPCServer(protoAddr string, app types.ABCIApplicationServer) service.Service {
+func NewGRPCServer(protoAddr string, app types.ABCIApplicationServer, options ...GRPCServerOption) service.Service {
 	proto, addr := tmnet.ProtocolAndAddress(protoAddr)
 	s := &GRPCServer{
 		proto:    proto,
@@ -30,6 +58,9 @@ func NewGRPCServer(protoAddr string, app types.ABCIApplicationServer) service.Se
 		listener: nil,
 		app:      app,
 	}
+	for _, option := range options {
+		option(s)
+	}
 	s.BaseService = *service.NewBaseService(nil, "ABCIServer", s)
 	return s
 }
@@ -43,7 +74,7 @@ func (s *GRPCServer) OnStart() error {
 	}
 
 	s.listener = ln
-	s.server = grpc.NewServer()
+	s.server = grpc.NewServer(s.serverOptions()...)
 	types.RegisterABCIApplicationServer(s.server, s.app)
 
 	s.Logger.Info("Listening", "proto", s.proto, "addr", s.addr)
@@ -55,6 +86,17 @@ func (s *GRPCServer) OnStart() error {
 	return nil
 }
 
+func (s *GRPCServer) serverOptions() []grpc.ServerOption {
+	var options []grpc.ServerOption
+	if s.keepaliveParams != nil {
+		options = append(options, grpc.KeepaliveParams(*s.keepaliveParams))
+	}
+	if s.keepalivePolicy != nil {
+		options = append(options, grpc.KeepaliveEnforcementPolicy(*s.keepalivePolicy))
+	}
+	return options
+}
+
 // OnStop stops the gRPC server.
 func (s *GRPCServer) OnStop() {
 	s.server.Stop()
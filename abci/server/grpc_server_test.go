@@ -0,0 +1,54 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/keepalive"
+
+	abcicli "github.com/line/ostracon/abci/client"
+	"github.com/line/ostracon/abci/example/kvstore"
+	tmrand "github.com/line/ostracon/libs/rand"
+)
+
+func TestGRPCServerKeepalive(t *testing.T) {
+	app := kvstore.NewApplication()
+
+	// the gRPC defaults
+	s, err := NewServer("tcp://127.0.0.1:0", "grpc", app)
+	require.NoError(t, err)
+	assert.Empty(t, s.(*GRPCServer).serverOptions())
+
+	socketFile := fmt.Sprintf("unix://%s/test-%08x.sock", t.TempDir(), tmrand.Int31n(1<<30))
+	s, err = NewServer(socketFile, "grpc", app,
+		WithReadTimeout(time.Second), // ignored by a gRPC server
+		WithKeepalive(30*time.Second, 5*time.Second, true))
+	require.NoError(t, err)
+	grpcServer := s.(*GRPCServer)
+	assert.Equal(t, &keepalive.ServerParameters{Time: 30 * time.Second, Timeout: 5 * time.Second},
+		grpcServer.keepaliveParams)
+	assert.Equal(t, &keepalive.EnforcementPolicy{MinTime: 30 * time.Second, PermitWithoutStream: true},
+		grpcServer.keepalivePolicy)
+	assert.Len(t, grpcServer.serverOptions(), 2)
+
+	// the server serves with the options
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	client := abcicli.NewGRPCClient(socketFile, true)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		if err := client.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	res, err := client.EchoSync("hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.Message)
+}
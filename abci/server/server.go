@@ -14,14 +14,43 @@ import (
 	"github.com/line/ostracon/libs/service"
 )
 
-func NewServer(protoAddr, transport string, app types.Application) (service.Service, error) {
+// ServerOption sets an optional parameter on the server returned by NewServer:
+// either a SocketServerOption or a GRPCServerOption, the options of the other
+// transport being ignored.
+type ServerOption interface {
+	isServerOption()
+}
+
+func (SocketServerOption) isServerOption() {}
+func (GRPCServerOption) isServerOption()   {}
+
+// NewServer returns a new ABCI server of the given transport, "socket" or
+// "grpc".
+func NewServer(
+	protoAddr, transport string,
+	app types.Application,
+	options ...ServerOption,
+) (service.Service, error) {
+	var (
+		socketOptions []SocketServerOption
+		grpcOptions   []GRPCServerOption
+	)
+	for _, option := range options {
+		switch option := option.(type) {
+		case SocketServerOption:
+			socketOptions = append(socketOptions, option)
+		case GRPCServerOption:
+			grpcOptions = append(grpcOptions, option)
+		}
+	}
+
 	var s service.Service
 	var err error
 	switch transport {
 	case "socket":
-		s = NewSocketServer(protoAddr, app)
+		s = NewSocketServer(protoAddr, app, socketOptions...)
 	case "grpc":
-		s = NewGRPCServer(protoAddr, types.NewGRPCApplication(app))
+		s = NewGRPCServer(protoAddr, types.NewGRPCApplication(app), grpcOptions...)
 	default:
 		err = fmt.Errorf("unknown server type %s", transport)
 	}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"runtime"
+	"time"
 
 	"github.com/line/ostracon/abci/types"
 	tmlog "github.com/line/ostracon/libs/log"
@@ -31,9 +32,69 @@ type SocketServer struct {
 
 	appMtx tmsync.Mutex
 	app    types.Application
+
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+
+	readBufferSize  int
+	writeBufferSize int
+}
+
+// SocketServerOption sets an optional parameter on the SocketServer.
+type SocketServerOption func(*SocketServer)
+
+// WithReadTimeout sets the maximum time to wait for the next request on a
+// connection. The deadline is reset before every request, so it bounds the
+// time a client may stay idle, and a stalled client is disconnected once it
+// expires. Zero, the default, means no deadline.
+func WithReadTimeout(timeout time.Duration) SocketServerOption {
+	return func(s *SocketServer) { s.readTimeout = timeout }
+}
+
+// WithWriteTimeout sets the maximum time to write a response to a connection.
+// The deadline is reset before every write, and a client not reading its
+// responses is disconnected once it expires. Zero, the default, means no
+// deadline.
+func WithWriteTimeout(timeout time.Duration) SocketServerOption {
+	return func(s *SocketServer) { s.writeTimeout = timeout }
+}
+
+// WithReadBufferSize sets the size of the operating system's receive buffer of
+// each connection. Zero, the default, keeps the operating system's default.
+func WithReadBufferSize(size int) SocketServerOption {
+	return func(s *SocketServer) { s.readBufferSize = size }
+}
+
+// WithWriteBufferSize sets the size of the operating system's transmit buffer
+// of each connection. Zero, the default, keeps the operating system's default.
+func WithWriteBufferSize(size int) SocketServerOption {
+	return func(s *SocketServer) { s.writeBufferSize = size }
+}
+
+// bufferedConn is a connection whose buffer sizes can be set, e.g. the TCP and
+// unix connections.
+type bufferedConn interface {
+	SetReadBuffer(bytes int) error
+	SetWriteBuffer(bytes int) error
 }
 
-func NewSocketServer(protoAddr string, app types.Application) service.Service {
+// setBufferSizes sets the non-zero buffer sizes of a connection. It's a
+// variable for the tests.
+var setBufferSizes = func(conn bufferedConn, readSize, writeSize int) error {
+	if readSize > 0 {
+		if err := conn.SetReadBuffer(readSize); err != nil {
+			return err
+		}
+	}
+	if writeSize > 0 {
+		if err := conn.SetWriteBuffer(writeSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewSocketServer(protoAddr string, app types.Application, options ...SocketServerOption) service.Service {
 	proto, addr := tmnet.ProtocolAndAddress(protoAddr)
 	s := &SocketServer{
 		proto:    proto,
@@ -42,6 +103,9 @@ func NewSocketServer(protoAddr string, app types.Application) service.Service {
 		app:      app,
 		conns:    make(map[int]net.Conn),
 	}
+	for _, option := range options {
+		option(s)
+	}
 	s.BaseService = *service.NewBaseService(nil, "ABCIServer", s)
 	return s
 }
@@ -118,6 +182,14 @@ func (s *SocketServer) acceptConnectionsRoutine() {
 
 		s.Logger.Info("Accepted a new connection")
 
+		if s.readBufferSize > 0 || s.writeBufferSize > 0 {
+			if bc, ok := conn.(bufferedConn); !ok {
+				s.Logger.Error("Can't set the buffer sizes of the connection", "conn", conn)
+			} else if err := setBufferSizes(bc, s.readBufferSize, s.writeBufferSize); err != nil {
+				s.Logger.Error("Failed to set the buffer sizes of the connection", "err", err)
+			}
+		}
+
 		connID := s.addConn(conn)
 
 		closeConn := make(chan error, 2)              // Push to signal connection closed
@@ -152,7 +224,7 @@ func (s *SocketServer) waitForClose(closeConn chan error, connID int) {
 }
 
 // Read requests from conn and deal with them
-func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, responses chan<- *types.Response) {
+func (s *SocketServer) handleRequests(closeConn chan error, conn net.Conn, responses chan<- *types.Response) {
 	var count int
 	var bufReader = bufio.NewReader(conn)
 
@@ -174,6 +246,13 @@ func (s *SocketServer) handleRequests(closeConn chan error, conn io.Reader, resp
 
 	for {
 
+		if s.readTimeout > 0 {
+			if err := conn.SetReadDeadline(time.Now().Add(s.readTimeout)); err != nil {
+				closeConn <- fmt.Errorf("error setting read deadline: %w", err)
+				return
+			}
+		}
+
 		var req = &types.Request{}
 		err := types.ReadMessage(bufReader, req)
 		if err != nil {
@@ -248,11 +327,18 @@ func (s *SocketServer) handleRequest(req *types.Request, responses chan<- *types
 }
 
 // Pull responses from 'responses' and write them to conn.
-func (s *SocketServer) handleResponses(closeConn chan error, conn io.Writer, responses <-chan *types.Response) {
+func (s *SocketServer) handleResponses(closeConn chan error, conn net.Conn, responses <-chan *types.Response) {
 	var count int
 	var bufWriter = bufio.NewWriter(conn)
 	for {
 		var res = <-responses
+		// the buffered writer may write to conn on any message, not only on flush
+		if s.writeTimeout > 0 {
+			if err := conn.SetWriteDeadline(time.Now().Add(s.writeTimeout)); err != nil {
+				closeConn <- fmt.Errorf("error setting write deadline: %w", err)
+				return
+			}
+		}
 		err := types.WriteMessage(res, bufWriter)
 		if err != nil {
 			closeConn <- fmt.Errorf("error writing message: %w", err)
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/line/ostracon/abci/example/kvstore"
+	tmrand "github.com/line/ostracon/libs/rand"
+)
+
+func TestSocketServerBufferSizes(t *testing.T) {
+	type sizes struct{ read, write int }
+	set := make(chan sizes, 1)
+	defaultSetBufferSizes := setBufferSizes
+	setBufferSizes = func(conn bufferedConn, readSize, writeSize int) error {
+		set <- sizes{readSize, writeSize}
+		return defaultSetBufferSizes(conn, readSize, writeSize)
+	}
+	t.Cleanup(func() { setBufferSizes = defaultSetBufferSizes })
+
+	connect := func(options ...ServerOption) {
+		socketFile := fmt.Sprintf("%s/test-%08x.sock", t.TempDir(), tmrand.Int31n(1<<30))
+		s, err := NewServer("unix://"+socketFile, "socket", kvstore.NewApplication(), options...)
+		require.NoError(t, err)
+		require.NoError(t, s.Start())
+		t.Cleanup(func() {
+			if err := s.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		conn, err := net.Dial("unix", socketFile)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+	}
+
+	connect(WithReadBufferSize(1<<20), WithWriteBufferSize(1<<19))
+	select {
+	case got := <-set:
+		assert.Equal(t, sizes{1 << 20, 1 << 19}, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the buffer sizes weren't set")
+	}
+
+	// the defaults keep the buffers of the operating system
+	connect()
+	select {
+	case got := <-set:
+		t.Fatalf("unexpected buffer sizes %v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
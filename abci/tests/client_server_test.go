@@ -1,13 +1,18 @@
 package tests
 
 import (
+	"fmt"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	abciclient "github.com/line/ostracon/abci/client"
 	"github.com/line/ostracon/abci/example/kvstore"
 	abciserver "github.com/line/ostracon/abci/server"
+	tmrand "github.com/line/ostracon/libs/rand"
 )
 
 func TestClientServerNoAddrPrefix(t *testing.T) {
@@ -25,3 +30,32 @@ func TestClientServerNoAddrPrefix(t *testing.T) {
 	err = client.Start()
 	assert.NoError(t, err, "expected no error on client.Start")
 }
+
+func TestSocketServerReadTimeout(t *testing.T) {
+	socketFile := fmt.Sprintf("%s/test-%08x.sock", t.TempDir(), tmrand.Int31n(1<<30))
+	app := kvstore.NewApplication()
+
+	server, err := abciserver.NewServer("unix://"+socketFile, "socket", app,
+		abciserver.WithReadTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		if err := server.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	// a client that connects but never sends a request
+	start := time.Now()
+	conn, err := net.Dial("unix", socketFile)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.SetReadDeadline(start.Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	require.Error(t, err)
+	if netErr, ok := err.(net.Error); ok {
+		require.False(t, netErr.Timeout(), "expected the server to close the connection")
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
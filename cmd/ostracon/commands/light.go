@@ -205,6 +205,9 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	cfg.MaxBodyBytes = config.RPC.MaxBodyBytes
 	cfg.MaxHeaderBytes = config.RPC.MaxHeaderBytes
 	cfg.MaxOpenConnections = maxOpenConnections
+	cfg.ListenBacklog = config.RPC.ListenBacklog
+	cfg.MaxSubscriptionsPerConnection = config.RPC.MaxSubscriptionsPerClient
+	cfg.MaxSubscriptions = config.RPC.MaxSubscriptions
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
@@ -239,6 +239,10 @@ type BaseConfig struct { //nolint: maligned
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// How long to wait for the ABCI application to respond to Info during
+	// the handshake on startup. 0 means no timeout.
+	ABCIHandshakeTimeout time.Duration `mapstructure:"abci_handshake_timeout"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
@@ -247,23 +251,24 @@ type BaseConfig struct { //nolint: maligned
 // DefaultBaseConfig returns a default base configuration for an Ostracon node
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		Genesis:            defaultGenesisJSONPath,
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               "socket",
-		LogLevel:           DefaultPackageLogLevels(),
-		LogFormat:          LogFormatPlain,
-		LogPath:            "",
-		LogMaxAge:          0,
-		LogMaxSize:         100,
-		LogMaxBackups:      0,
-		FastSyncMode:       true,
-		FilterPeers:        false,
-		DBBackend:          DefaultDBBackend,
-		DBPath:             "data",
+		Genesis:              defaultGenesisJSONPath,
+		PrivValidatorKey:     defaultPrivValKeyPath,
+		PrivValidatorState:   defaultPrivValStatePath,
+		NodeKey:              defaultNodeKeyPath,
+		Moniker:              defaultMoniker,
+		ProxyApp:             "tcp://127.0.0.1:26658",
+		ABCI:                 "socket",
+		ABCIHandshakeTimeout: time.Minute,
+		LogLevel:             DefaultPackageLogLevels(),
+		LogFormat:            LogFormatPlain,
+		LogPath:              "",
+		LogMaxAge:            0,
+		LogMaxSize:           100,
+		LogMaxBackups:        0,
+		FastSyncMode:         true,
+		FilterPeers:          false,
+		DBBackend:            DefaultDBBackend,
+		DBPath:               "data",
 	}
 }
 
@@ -314,6 +319,9 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+	if cfg.ABCIHandshakeTimeout < 0 {
+		return errors.New("abci_handshake_timeout can't be negative")
+	}
 	return nil
 }
 
@@ -368,6 +376,12 @@ type RPCConfig struct {
 	// 1024 - 40 - 10 - 50 = 924 = ~900
 	MaxOpenConnections int `mapstructure:"max_open_connections"`
 
+	// Maximum length of the queue of pending connections of the listener.
+	// Raise it (and the OS limit, net.core.somaxconn on Linux) if connections
+	// are dropped under bursts.
+	// 0 - the OS default.
+	ListenBacklog int `mapstructure:"listen_backlog"`
+
 	// mirrors http.Server#ReadTimeout
 	// ReadTimeout is the maximum duration for reading the entire
 	// request, including the body.
@@ -402,6 +416,12 @@ type RPCConfig struct {
 	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max_subscriptions_per_client"`
 
+	// Maximum number of subscriptions of all the WebSocket connections.
+	// Unlike max_subscriptions_per_client, which also applies to each WebSocket
+	// connection, it bounds the subscriptions of the whole server.
+	// 0 - unlimited.
+	MaxSubscriptions int `mapstructure:"max_subscriptions"`
+
 	// The number of events that can be buffered per subscription before
 	// returning `ErrOutOfCapacity`.
 	SubscriptionBufferSize int `mapstructure:"experimental_subscription_buffer_size"`
@@ -437,6 +457,14 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
 
+	// Maximum number of txs of a /broadcast_txs request
+	// 0 - unlimited.
+	MaxBroadcastTxsBatchSize int `mapstructure:"max_broadcast_txs_batch_size"`
+
+	// Size, in bytes, of the chunks the genesis document is split into by
+	// /genesis_chunked. 0 means the default size (16MB)
+	GenesisChunkSize int `mapstructure:"genesis_chunk_size"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to Ostracon's config directory.
 	//
@@ -457,6 +485,14 @@ type RPCConfig struct {
 
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	PprofListenAddress string `mapstructure:"pprof_laddr"`
+
+	// Mount the pprof (/debug/pprof/) and expvar (/debug/vars) handlers on
+	// the RPC server, i.e. without a separate listener like pprof_laddr.
+	PprofOnRPC bool `mapstructure:"pprof_on_rpc"`
+
+	// If not empty, the handlers mounted by pprof_on_rpc require the
+	// "Authorization: Bearer <token>" header with this token.
+	PprofOnRPCAuthToken string `mapstructure:"pprof_on_rpc_auth_token"`
 }
 
 // DefaultRPCConfig returns a default configuration for the RPC server
@@ -484,6 +520,10 @@ func DefaultRPCConfig() *RPCConfig {
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		MaxBroadcastTxsBatchSize: 100,
+
+		GenesisChunkSize: 16 * 1024 * 1024, // 16MB
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
 	}
@@ -522,6 +562,9 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxSubscriptionsPerClient < 0 {
 		return errors.New("max_subscriptions_per_client can't be negative")
 	}
+	if cfg.MaxSubscriptions < 0 {
+		return errors.New("max_subscriptions can't be negative")
+	}
 	if cfg.SubscriptionBufferSize < minSubscriptionBufferSize {
 		return fmt.Errorf(
 			"experimental_subscription_buffer_size must be >= %d",
@@ -543,6 +586,15 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.MaxBroadcastTxsBatchSize < 0 {
+		return errors.New("max_broadcast_txs_batch_size can't be negative")
+	}
+	if cfg.ListenBacklog < 0 {
+		return errors.New("listen_backlog can't be negative")
+	}
+	if cfg.GenesisChunkSize < 0 {
+		return errors.New("genesis_chunk_size can't be negative")
+	}
 	return nil
 }
 
@@ -613,6 +665,10 @@ type P2PConfig struct { //nolint: maligned
 	// Maximum pause when redialing a persistent peer (if zero, exponential backoff is used)
 	PersistentPeersMaxDialPeriod time.Duration `mapstructure:"persistent_peers_max_dial_period"`
 
+	// Time the IPs the hosts of the peer addresses resolve to are cached for.
+	// If a resolution fails, the last IPs are used. 0 disables the cache.
+	DNSCacheTTL time.Duration `mapstructure:"dns_cache_ttl"`
+
 	// Time to wait before flushing messages out on the connection
 	FlushThrottleTimeout time.Duration `mapstructure:"flush_throttle_timeout"`
 
@@ -675,6 +731,7 @@ func DefaultP2PConfig() *P2PConfig {
 		MaxNumInboundPeers:           40,
 		MaxNumOutboundPeers:          10,
 		PersistentPeersMaxDialPeriod: 0 * time.Second,
+		DNSCacheTTL:                  0 * time.Second,
 		FlushThrottleTimeout:         100 * time.Millisecond,
 		MaxPacketMsgPayloadSize:      1024,    // 1 kB
 		SendRate:                     5120000, // 5 mB/s
@@ -720,6 +777,9 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.MaxNumOutboundPeers < 0 {
 		return errors.New("max_num_outbound_peers can't be negative")
 	}
+	if cfg.DNSCacheTTL < 0 {
+		return errors.New("dns_cache_ttl can't be negative")
+	}
 	if cfg.FlushThrottleTimeout < 0 {
 		return errors.New("flush_throttle_timeout can't be negative")
 	}
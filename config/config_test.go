@@ -73,9 +73,13 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"MaxOpenConnections",
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
+		"MaxSubscriptions",
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"MaxBroadcastTxsBatchSize",
+		"ListenBacklog",
+		"GenesisChunkSize",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -92,6 +96,7 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 	fieldsToTest := []string{
 		"MaxNumInboundPeers",
 		"MaxNumOutboundPeers",
+		"DNSCacheTTL",
 		"FlushThrottleTimeout",
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
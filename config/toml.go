@@ -164,6 +164,10 @@ node_key_file = "{{ js .BaseConfig.NodeKey }}"
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# How long to wait for the ABCI application to respond to Info during the
+# handshake on startup. 0 means no timeout.
+abci_handshake_timeout = "{{ .BaseConfig.ABCIHandshakeTimeout }}"
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter_peers = {{ .BaseConfig.FilterPeers }}
@@ -217,6 +221,12 @@ unsafe = {{ .RPC.Unsafe }}
 # 1024 - 40 - 10 - 50 = 924 = ~900
 max_open_connections = {{ .RPC.MaxOpenConnections }}
 
+# Maximum length of the queue of pending connections of the listener.
+# Raise it (and the OS limit, net.core.somaxconn on Linux) if connections
+# are dropped under bursts.
+# 0 - the OS default.
+listen_backlog = {{ .RPC.ListenBacklog }}
+
 # mirrors http.Server#ReadTimeout
 # ReadTimeout is the maximum duration for reading the entire
 # request, including the body.
@@ -250,6 +260,12 @@ max_subscription_clients = {{ .RPC.MaxSubscriptionClients }}
 # the estimated # maximum number of broadcast_tx_commit calls per block.
 max_subscriptions_per_client = {{ .RPC.MaxSubscriptionsPerClient }}
 
+# Maximum number of subscriptions of all the WebSocket connections.
+# Unlike max_subscriptions_per_client, which also applies to each WebSocket
+# connection, it bounds the subscriptions of the whole server.
+# 0 - unlimited.
+max_subscriptions = {{ .RPC.MaxSubscriptions }}
+
 # Experimental parameter to specify the maximum number of events a node will
 # buffer, per subscription, before returning an error and closing the
 # subscription. Must be set to at least 100, but higher values will accommodate
@@ -289,6 +305,14 @@ max_body_bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max_header_bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Maximum number of txs of a /broadcast_txs request
+# 0 - unlimited.
+max_broadcast_txs_batch_size = {{ .RPC.MaxBroadcastTxsBatchSize }}
+
+# Size of the chunks the genesis document is split into by /genesis_chunked, in bytes
+# 0 means the default size (16MB)
+genesis_chunk_size = {{ .RPC.GenesisChunkSize }}
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to Ostracon's config directory.
 # If the certificate is signed by a certificate authority,
@@ -307,6 +331,14 @@ tls_key_file = "{{ .RPC.TLSKeyFile }}"
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof_laddr = "{{ .RPC.PprofListenAddress }}"
 
+# Mount the pprof (/debug/pprof/) and expvar (/debug/vars) handlers on the RPC
+# server, i.e. without a separate listener like pprof_laddr.
+pprof_on_rpc = {{ .RPC.PprofOnRPC }}
+
+# If not empty, the handlers mounted by pprof_on_rpc require the
+# "Authorization: Bearer <token>" header with this token.
+pprof_on_rpc_auth_token = "{{ .RPC.PprofOnRPCAuthToken }}"
+
 #######################################################
 ###           P2P Configuration Options             ###
 #######################################################
@@ -350,6 +382,10 @@ unconditional_peer_ids = "{{ .P2P.UnconditionalPeerIDs }}"
 # Maximum pause when redialing a persistent peer (if zero, exponential backoff is used)
 persistent_peers_max_dial_period = "{{ .P2P.PersistentPeersMaxDialPeriod }}"
 
+# Time the IPs the hosts of the peer addresses resolve to are cached for.
+# If a resolution fails, the last IPs are used. 0 disables the cache.
+dns_cache_ttl = "{{ .P2P.DNSCacheTTL }}"
+
 # Time to wait before flushing messages out on the connection
 flush_throttle_timeout = "{{ .P2P.FlushThrottleTimeout }}"
 
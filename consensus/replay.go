@@ -205,6 +205,7 @@ type Handshaker struct {
 	eventBus     types.BlockEventPublisher
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
+	infoTimeout  time.Duration
 
 	nBlocks int // number of blocks applied to the state
 }
@@ -233,6 +234,12 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetInfoTimeout sets how long Handshake waits for the app to respond to Info.
+// If not called or 0, it waits indefinitely.
+func (h *Handshaker) SetInfoTimeout(timeout time.Duration) {
+	h.infoTimeout = timeout
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
@@ -242,7 +249,7 @@ func (h *Handshaker) NBlocks() int {
 func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 
 	// Handshake is done via ABCI Info on the query conn.
-	res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	res, err := h.requestInfo(proxyApp.Query())
 	if err != nil {
 		return fmt.Errorf("error calling Info: %v", err)
 	}
@@ -280,6 +287,32 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 	return nil
 }
 
+// requestInfo calls Info, giving up after the info timeout if any.
+func (h *Handshaker) requestInfo(query proxy.AppConnQuery) (*abci.ResponseInfo, error) {
+	if h.infoTimeout <= 0 {
+		return query.InfoSync(proxy.RequestInfo)
+	}
+
+	type result struct {
+		res *abci.ResponseInfo
+		err error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		res, err := query.InfoSync(proxy.RequestInfo)
+		resCh <- result{res, err}
+	}()
+
+	timer := time.NewTimer(h.infoTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-resCh:
+		return r.res, r.err
+	case <-timer.C:
+		return nil, fmt.Errorf("app did not respond within %v", h.infoTimeout)
+	}
+}
+
 // ReplayBlocks replays all blocks since appBlockHeight and ensures the result
 // matches the current state.
 // Returns the final AppHash or an error.
@@ -334,12 +367,12 @@ func (h *Handshaker) ReplayBlocks(
 			}
 			// If the app returned validators or consensus params, update the state.
 			if len(res.Validators) > 0 {
-				vals, err := types.PB2OC.ValidatorUpdates(res.Validators)
+				vals, err := types.ValidatorSetFromInitChain(res.Validators)
 				if err != nil {
 					return nil, err
 				}
-				state.Validators = types.NewValidatorSet(vals)
-				state.NextValidators = types.NewValidatorSet(vals)
+				state.Validators = vals
+				state.NextValidators = vals.Copy()
 			} else if len(h.genDoc.Validators) == 0 {
 				// If validator set is not set in genesis and still empty after InitChain, exit.
 				return nil, fmt.Errorf("validator set is nil in genesis and still empty after InitChain")
@@ -1303,3 +1303,43 @@ func (ica *initChainApp) InitChain(req abci.RequestInitChain) abci.ResponseInitC
 		Validators: ica.vals,
 	}
 }
+
+func TestHandshakeInfoTimeout(t *testing.T) {
+	config := ResetConfig("handshake_test_")
+	defer os.RemoveAll(config.RootDir)
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(config, pubKey, version.AppProtocol)
+	stateStore := sm.NewStore(stateDB)
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+
+	app := &unresponsiveApp{release: make(chan struct{})}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	t.Cleanup(func() {
+		if err := proxyApp.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	t.Cleanup(func() { close(app.release) })
+
+	handshaker := NewHandshaker(stateStore, state, store, genDoc)
+	handshaker.SetInfoTimeout(100 * time.Millisecond)
+	start := time.Now()
+	err = handshaker.Handshake(proxyApp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app did not respond within 100ms")
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+// doesn't respond to Info until released
+type unresponsiveApp struct {
+	ocabci.BaseApplication
+	release chan struct{}
+}
+
+func (app *unresponsiveApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	<-app.release
+	return abci.ResponseInfo{}
+}
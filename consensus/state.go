@@ -1043,7 +1043,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 	logger.Debug("entering new round", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
 	// Select the current height and round Proposer
-	cs.Proposer = cs.Validators.SelectProposer(cs.state.LastProofHash, height, round)
+	cs.Proposer = cs.Validators.SelectProposerWithParams(cs.state.OCConsensusParams, cs.state.LastProofHash, height, round)
 
 	// Setup new round
 	// we don't fire newStep for this step,
@@ -1919,7 +1919,8 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 	}
 
 	// If consensus does not enterNewRound yet, cs.Proposer may be nil or prior proposer, so don't use cs.Proposer
-	proposer := cs.Validators.SelectProposer(cs.state.LastProofHash, proposal.Height, proposal.Round)
+	proposer := cs.Validators.SelectProposerWithParams(
+		cs.state.OCConsensusParams, cs.state.LastProofHash, proposal.Height, proposal.Round)
 
 	p := proposal.ToProto()
 	// Verify signature
@@ -62,3 +62,31 @@ func PubKeyFromProto(k *pc.PublicKey) (crypto.PubKey, error) {
 		return nil, fmt.Errorf("fromproto: key type %v is not supported", k)
 	}
 }
+
+// PubKeysToProto transforms a slice of crypto.PubKey to protobuf Pubkeys. It
+// stops at the first key that can't be transformed and reports its index.
+func PubKeysToProto(ks []crypto.PubKey) ([]pc.PublicKey, error) {
+	kps := make([]pc.PublicKey, len(ks))
+	for i, k := range ks {
+		kp, err := PubKeyToProto(k)
+		if err != nil {
+			return nil, fmt.Errorf("pubkey #%d: %w", i, err)
+		}
+		kps[i] = kp
+	}
+	return kps, nil
+}
+
+// PubKeysFromProto transforms a slice of protobuf Pubkeys to crypto.PubKey. It
+// stops at the first key that can't be transformed and reports its index.
+func PubKeysFromProto(kps []pc.PublicKey) ([]crypto.PubKey, error) {
+	ks := make([]crypto.PubKey, len(kps))
+	for i := range kps {
+		k, err := PubKeyFromProto(&kps[i])
+		if err != nil {
+			return nil, fmt.Errorf("pubkey #%d: %w", i, err)
+		}
+		ks[i] = k
+	}
+	return ks, nil
+}
@@ -4,8 +4,14 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pc "github.com/tendermint/tendermint/proto/tendermint/crypto"
+
 	"github.com/line/ostracon/crypto"
 	"github.com/line/ostracon/crypto/ed25519"
+	"github.com/line/ostracon/crypto/secp256k1"
 )
 
 func testPubKeyFromToProto(t *testing.T, sk crypto.PrivKey) {
@@ -29,3 +35,32 @@ func testPubKeyFromToProto(t *testing.T, sk crypto.PrivKey) {
 func TestPubKeyFromToProto(t *testing.T) {
 	testPubKeyFromToProto(t, ed25519.GenPrivKey())
 }
+
+func TestPubKeysFromToProto(t *testing.T) {
+	pks := []crypto.PubKey{
+		ed25519.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(),
+		ed25519.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(),
+	}
+	pbPubKeys, err := PubKeysToProto(pks)
+	require.NoError(t, err)
+	require.Len(t, pbPubKeys, len(pks))
+
+	pks2, err := PubKeysFromProto(pbPubKeys)
+	require.NoError(t, err)
+	require.Len(t, pks2, len(pks))
+	for i := range pks {
+		assert.Equal(t, reflect.TypeOf(pks[i]), reflect.TypeOf(pks2[i]))
+		assert.True(t, pks2[i].Equals(pks[i]))
+	}
+
+	// the index of the first bad key is reported
+	_, err = PubKeysToProto(append(pks, nil))
+	assert.EqualError(t, err, "pubkey #4: toproto: key type <nil> is not supported")
+	pbPubKeys[2] = pc.PublicKey{Sum: &pc.PublicKey_Ed25519{Ed25519: []byte{1, 2, 3}}}
+	_, err = PubKeysFromProto(pbPubKeys)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "pubkey #2:")
+	}
+}
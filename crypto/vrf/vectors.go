@@ -0,0 +1,114 @@
+package vrf
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// TestVector is a known-answer test case for the VRF implementation in use.
+// Ports of the VRF to other languages (e.g. light clients) can validate their
+// interoperability against the same vectors.
+//
+// The proof is randomized by the prover, so a fresh proof over Message does not
+// have to equal Proof; it must however verify and yield the same Output.
+type TestVector struct {
+	Seed      []byte // ed25519 private key seed
+	PublicKey []byte
+	Message   []byte
+	Proof     Proof
+	Output    Output
+}
+
+type testVectorHex struct {
+	Seed      string
+	PublicKey string
+	Message   string
+	Proof     string
+	Output    string
+}
+
+// TestVectors returns the fixed known-answer vectors for the VRF implementation
+// selected at build time.
+func TestVectors() []TestVector {
+	vectors := make([]TestVector, len(testVectorsHex))
+	for i, v := range testVectorsHex {
+		vectors[i] = TestVector{
+			Seed:      mustDecodeHex(v.Seed),
+			PublicKey: mustDecodeHex(v.PublicKey),
+			Message:   mustDecodeHex(v.Message),
+			Proof:     mustDecodeHex(v.Proof),
+			Output:    mustDecodeHex(v.Output),
+		}
+	}
+	return vectors
+}
+
+// SelfTest runs the VRF implementation selected at build time against
+// TestVectors and returns an error describing the first mismatch.
+func SelfTest() error {
+	return selfTest(TestVectors())
+}
+
+func selfTest(vectors []TestVector) error {
+	if len(vectors) == 0 {
+		return errors.New("no test vectors for this VRF implementation")
+	}
+	for i, v := range vectors {
+		if err := v.check(); err != nil {
+			return fmt.Errorf("test vector #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (v TestVector) check() error {
+	if len(v.Seed) != ed25519.SeedSize {
+		return fmt.Errorf("invalid seed size: %d", len(v.Seed))
+	}
+	privateKey := ed25519.NewKeyFromSeed(v.Seed)
+	if publicKey := privateKey.Public().(ed25519.PublicKey); !bytes.Equal(publicKey, v.PublicKey) {
+		return fmt.Errorf("public key mismatch: expected %X, got %X", v.PublicKey, []byte(publicKey))
+	}
+
+	// the recorded proof verifies and hashes to the recorded output
+	valid, err := Verify(v.PublicKey, v.Proof, v.Message)
+	if err != nil || !valid {
+		return fmt.Errorf("recorded proof does not verify: %v", err)
+	}
+	output, err := ProofToHash(v.Proof)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(output, v.Output) {
+		return fmt.Errorf("output mismatch: expected %X, got %X", v.Output, output)
+	}
+
+	// a fresh proof verifies and yields the same output
+	proof, err := Prove(privateKey, v.Message)
+	if err != nil {
+		return err
+	}
+	valid, err = Verify(v.PublicKey, proof, v.Message)
+	if err != nil || !valid {
+		return fmt.Errorf("generated proof does not verify: %v", err)
+	}
+	output, err = ProofToHash(proof)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(output, v.Output) {
+		return fmt.Errorf("generated output mismatch: expected %X, got %X", v.Output, output)
+	}
+	return nil
+}
+
+func mustDecodeHex(s string) []byte {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
@@ -0,0 +1,8 @@
+//go:build libsodium || coniks
+// +build libsodium coniks
+
+package vrf
+
+// testVectorsHex is empty: known answers are only published for the default
+// (r2ishiguro) implementation.
+var testVectorsHex []testVectorHex
@@ -0,0 +1,36 @@
+//go:build !libsodium && !coniks
+// +build !libsodium,!coniks
+
+package vrf
+
+// testVectorsHex are the known answers of the r2ishiguro implementation.
+var testVectorsHex = []testVectorHex{
+	{
+		Seed:      "62168CA0644A8F3E3B31D083CD913D031858FEA9B09532CB0E0C284CD576E688",
+		PublicKey: "7A2166D57D8326DB3070AB5D5BC8560E688558DC4126A5E9CD83700114F62985",
+		Message:   "",
+		Proof:     "0259FE7B15EF62C9A2B46F8C2B99527C4424792C35068C721412805C89F6CED112097A5BDA06FA7AFBF962602A77A04E2C0C84DC7BA07F2DF1059A9318A99DD8CDB1154AD69561B50D0A2B05BEA8F2BD86",
+		Output:    "59FE7B15EF62C9A2B46F8C2B99527C4424792C35068C721412805C89F6CED112",
+	},
+	{
+		Seed:      "8EF3A3A6C0ACE292037CD1221267D88D90F6DB1F15C578C3B002AE6E016127BB",
+		PublicKey: "7D6A8AB703357199E857AD3B987CC107A75F62C2AB884455C23D1D8F46FD8458",
+		Message:   "73616D706C65",
+		Proof:     "02BDD3726A5896AA2B370C348A64E755D7E83AF0D5652ED18E39E1C8AABAFD731DDAD8BC8E1FD47FDAB52D941A48C37C430DEF395D56682491060CFC89B68B2AEC63DB50A41142FE2C5E7B9431D58B5D19",
+		Output:    "BDD3726A5896AA2B370C348A64E755D7E83AF0D5652ED18E39E1C8AABAFD731D",
+	},
+	{
+		Seed:      "70B150D83669620514E42E670CA5B88A708206C87DFBC44A6FA00E30355935EF",
+		PublicKey: "8FF6927E10C65C021ED472EB5E51A63CD314D06CD77BE2BD7009BD6087505AF4",
+		Message:   "68656C6C6F2C20776F726C64",
+		Proof:     "02B36D1AEA8945EEDE61632F84D356D08A6B0422E692A67695CDF56D64B5507D1090A24345661BA32726590B1B73D30FC30AD2340D6DCB4281EDF738FAC41A2FB516D7DB8285319644120BB0AE4239AC96",
+		Output:    "B36D1AEA8945EEDE61632F84D356D08A6B0422E692A67695CDF56D64B5507D10",
+	},
+	{
+		Seed:      "EB3224C8E88EEF63B902FDBF002BF29DF77CDF2839D9E4BF6DC59B09E48304E5",
+		PublicKey: "43122A075A4B59B78216334B5777596A011746E7CE91794AD50454160D154DC8",
+		Message:   "6F73747261636F6E",
+		Proof:     "02D46F904C0E145CC73821886E318CD3ED8B459E963F492F4057C9181F984C3A6E072CB4D84FDD756C778B8F370861AA090255003CC96216B1CECAC393EC45FE238990CC26A94660ED90DDCDE6E9EF20BD",
+		Output:    "D46F904C0E145CC73821886E318CD3ED8B459E963F492F4057C9181F984C3A6E",
+	},
+}
@@ -1,9 +1,26 @@
 package vrf
 
 import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
 	"math/big"
+	"sync/atomic"
 )
 
+var (
+	// ErrVRFUnavailable is returned when no VRF implementation has been
+	// initialized, e.g. because of a missing CGO dependency.
+	ErrVRFUnavailable = errors.New("VRF implementation is unavailable")
+
+	// ErrVRFSelfCheckFailed is returned by Prove, when the self-check is
+	// enabled, if the proof it produced doesn't verify.
+	ErrVRFSelfCheckFailed = errors.New("VRF self-check failed")
+)
+
+// selfCheck is non-zero if Prove verifies its own proofs, see SetSelfCheck.
+var selfCheck int32
+
 // defaultVrf is assigned to vrfEd25519r2ishiguro by init() of vrf_r2ishguro.go
 // If you want to use libsodium for vrf implementation, then you should put build option like this
 // `make build LIBSODIUM=1`
@@ -14,6 +31,8 @@ type Proof []byte
 type Output []byte
 
 type vrfEd25519 interface {
+	// Name returns the name of the library implementing the VRF.
+	Name() string
 	Prove(privateKey []byte, message []byte) (Proof, error)
 	Verify(publicKey []byte, proof Proof, message []byte) (bool, error)
 	ProofToHash(proof Proof) (Output, error)
@@ -25,14 +44,88 @@ func (op Output) ToInt() *big.Int {
 	return &i
 }
 
+// Available returns ErrVRFUnavailable if no VRF implementation has been
+// initialized.
+func Available() error {
+	if defaultVrf == nil {
+		return ErrVRFUnavailable
+	}
+	return nil
+}
+
+// Backend returns the name of the library implementing the VRF (e.g.
+// "r2ishiguro", selected by build tags), or an empty string if no VRF
+// implementation has been initialized.
+func Backend() string {
+	if defaultVrf == nil {
+		return ""
+	}
+	return defaultVrf.Name()
+}
+
+// SetSelfCheck enables or disables the self-check of Prove: when enabled, every
+// proof is hashed with ProofToHash and verified against the public key of the
+// private key before being returned, and ErrVRFSelfCheckFailed is returned
+// instead of a bad proof. It is meant for debugging, e.g. after upgrading the
+// VRF library, and is disabled by default as it more than doubles the cost of
+// Prove.
+func SetSelfCheck(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&selfCheck, 1)
+	} else {
+		atomic.StoreInt32(&selfCheck, 0)
+	}
+}
+
+// SelfCheckEnabled returns true if the self-check of Prove is enabled.
+func SelfCheckEnabled() bool {
+	return atomic.LoadInt32(&selfCheck) != 0
+}
+
 func Prove(privateKey []byte, message []byte) (Proof, error) {
-	return defaultVrf.Prove(privateKey, message)
+	if defaultVrf == nil {
+		return nil, ErrVRFUnavailable
+	}
+	proof, err := defaultVrf.Prove(privateKey, message)
+	if err != nil || !SelfCheckEnabled() {
+		return proof, err
+	}
+	if err := checkProof(privateKey, proof, message); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// checkProof checks that proof, produced with privateKey, can be hashed and
+// verifies against the corresponding public key.
+func checkProof(privateKey []byte, proof Proof, message []byte) error {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return fmt.Errorf("%w: invalid private key size %d", ErrVRFSelfCheckFailed, len(privateKey))
+	}
+	if _, err := defaultVrf.ProofToHash(proof); err != nil {
+		return fmt.Errorf("%w: %v", ErrVRFSelfCheckFailed, err)
+	}
+	publicKey := ed25519.PrivateKey(privateKey).Public().(ed25519.PublicKey)
+	valid, err := defaultVrf.Verify(publicKey, proof, message)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrVRFSelfCheckFailed, err)
+	}
+	if !valid {
+		return fmt.Errorf("%w: proof doesn't verify", ErrVRFSelfCheckFailed)
+	}
+	return nil
 }
 
 func Verify(publicKey []byte, proof Proof, message []byte) (bool, error) {
+	if defaultVrf == nil {
+		return false, ErrVRFUnavailable
+	}
 	return defaultVrf.Verify(publicKey, proof, message)
 }
 
 func ProofToHash(proof Proof) (Output, error) {
+	if defaultVrf == nil {
+		return nil, ErrVRFUnavailable
+	}
 	return defaultVrf.ProofToHash(proof)
 }
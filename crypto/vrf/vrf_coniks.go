@@ -32,6 +32,10 @@ func newVrfEd25519coniksForVerifier(output Output, proof Proof) *vrfEd25519conik
 	return &vrfEd25519coniks{output, proof}
 }
 
+func (base *vrfEd25519coniks) Name() string {
+	return "coniks"
+}
+
 func (base *vrfEd25519coniks) Prove(privateKey []byte, message []byte) (Proof, error) {
 	if len(privateKey) != coniks.PrivateKeySize {
 		return nil, errors.New("private key size is invalid")
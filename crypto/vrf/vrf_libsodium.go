@@ -51,6 +51,10 @@ func (base vrfEd25519libsodium) Verify(publicKey []byte, proof Proof, message []
 	return bytes.Compare(op[:], hash) == 0, nil
 }
 
+func (base vrfEd25519libsodium) Name() string {
+	return "libsodium"
+}
+
 func (base vrfEd25519libsodium) ProofToHash(proof Proof) (Output, error) {
 	op, err := libsodium.ProofToHash(toArray(proof))
 	if err != nil {
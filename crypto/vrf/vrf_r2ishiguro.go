@@ -33,6 +33,10 @@ func (base vrfEd25519r2ishiguro) Prove(privateKey []byte, message []byte) (Proof
 	return r2ishiguro.ECVRF_prove(publicKey, privateKey, message)
 }
 
+func (base vrfEd25519r2ishiguro) Name() string {
+	return "r2ishiguro"
+}
+
 func (base vrfEd25519r2ishiguro) Verify(publicKey []byte, proof Proof, message []byte) (bool, error) {
 	return r2ishiguro.ECVRF_verify(publicKey, proof, message)
 }
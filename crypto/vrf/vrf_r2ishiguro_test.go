@@ -47,3 +47,18 @@ func TestProveAndVerifyR2ishiguroByCryptoEd25519(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, verified)
 }
+
+func TestSelfTest(t *testing.T) {
+	require.NoError(t, SelfTest())
+
+	vectors := TestVectors()
+	require.NotEmpty(t, vectors)
+	require.NoError(t, selfTest(vectors))
+
+	// altering a single vector makes the self test fail
+	vectors[1].Output[0] ^= 0x01
+	require.Error(t, selfTest(vectors))
+
+	// the returned vectors are copies
+	require.NoError(t, SelfTest())
+}
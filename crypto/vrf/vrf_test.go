@@ -121,3 +121,77 @@ func getAvalanche(a []byte, b []byte) (avalanche float32) {
 	avalanche = float32(count) / float32(len(a)*8)
 	return
 }
+
+func TestUnavailable(t *testing.T) {
+	impl := defaultVrf
+	defer func() { defaultVrf = impl }()
+	require.NoError(t, Available())
+
+	defaultVrf = nil
+	require.ErrorIs(t, Available(), ErrVRFUnavailable)
+
+	secret := [SEEDBYTES]byte{}
+	privateKey := ed25519.NewKeyFromSeed(secret[:])
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	message := []byte("hello, world")
+
+	proof, err := Prove(privateKey, message)
+	require.ErrorIs(t, err, ErrVRFUnavailable)
+	require.Nil(t, proof)
+
+	valid, err := Verify(publicKey, make(Proof, ProofSize), message)
+	require.ErrorIs(t, err, ErrVRFUnavailable)
+	require.False(t, valid)
+
+	output, err := ProofToHash(make(Proof, ProofSize))
+	require.ErrorIs(t, err, ErrVRFUnavailable)
+	require.Nil(t, output)
+}
+
+// corruptingVrf produces proofs that don't verify.
+type corruptingVrf struct {
+	vrfEd25519
+}
+
+func (v corruptingVrf) Prove(privateKey []byte, message []byte) (Proof, error) {
+	proof, err := v.vrfEd25519.Prove(privateKey, message)
+	if err != nil {
+		return nil, err
+	}
+	proof[len(proof)-1] ^= 0xff
+	return proof, nil
+}
+
+func TestSelfCheck(t *testing.T) {
+	impl := defaultVrf
+	defer func() {
+		defaultVrf = impl
+		SetSelfCheck(false)
+	}()
+	require.False(t, SelfCheckEnabled())
+
+	secret := [SEEDBYTES]byte{}
+	privateKey := ed25519.NewKeyFromSeed(secret[:])
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	message := []byte("hello, world")
+
+	// valid keys pass the self-check
+	SetSelfCheck(true)
+	require.True(t, SelfCheckEnabled())
+	verified, err := proveAndVerify(t, privateKey, publicKey)
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	// a bad proof is caught
+	defaultVrf = corruptingVrf{impl}
+	proof, err := Prove(privateKey, message)
+	require.ErrorIs(t, err, ErrVRFSelfCheckFailed)
+	require.Nil(t, proof)
+
+	// and slips through when the self-check is off
+	SetSelfCheck(false)
+	proof, err = Prove(privateKey, message)
+	require.NoError(t, err)
+	valid, _ := Verify(publicKey, proof, message)
+	require.False(t, valid)
+}
@@ -260,18 +260,6 @@ func (evpool *Pool) Close() error {
 	return evpool.evidenceStore.Close()
 }
 
-// IsExpired checks whether evidence or a polc is expired by checking whether a height and time is older
-// than set by the evidence consensus parameters
-func (evpool *Pool) isExpired(height int64, time time.Time) bool {
-	var (
-		params       = evpool.State().ConsensusParams.Evidence
-		ageDuration  = evpool.State().LastBlockTime.Sub(time)
-		ageNumBlocks = evpool.State().LastBlockHeight - height
-	)
-	return ageNumBlocks > params.MaxAgeNumBlocks &&
-		ageDuration > params.MaxAgeDuration
-}
-
 // IsCommitted returns true if we have already seen this exact evidence and it is already marked as committed.
 func (evpool *Pool) isCommitted(evidence types.Evidence) bool {
 	key := keyCommitted(evidence)
@@ -414,7 +402,8 @@ func (evpool *Pool) removeExpiredPendingEvidence() (int64, time.Time) {
 			evpool.logger.Error("Error in transition evidence from protobuf", "err", err)
 			continue
 		}
-		if !evpool.isExpired(ev.Height(), ev.Time()) {
+		state := evpool.State()
+		if types.EvidenceWithinWindow(ev, state.ConsensusParams, state.LastBlockHeight, state.LastBlockTime) {
 			if len(blockEvidenceMap) != 0 {
 				evpool.removeEvidenceFromList(blockEvidenceMap)
 			}
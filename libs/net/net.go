@@ -1,6 +1,8 @@
 package net
 
 import (
+	"errors"
+	"fmt"
 	"net"
 	"strings"
 )
@@ -17,6 +19,9 @@ func Connect(protoAddr string) (net.Conn, error) {
 // For instance, "tcp://127.0.0.1:8080" will be split into "tcp" and "127.0.0.1:8080".
 // If the address has no protocol prefix, the default is "tcp".
 func ProtocolAndAddress(listenAddr string) (string, string) {
+	if protocol, address, err := ParseEndpoint(listenAddr); err == nil {
+		return protocol, address
+	}
 	protocol, address := "tcp", listenAddr
 	parts := strings.SplitN(address, "://", 2)
 	if len(parts) == 2 {
@@ -25,6 +30,27 @@ func ProtocolAndAddress(listenAddr string) (string, string) {
 	return protocol, address
 }
 
+// ParseEndpoint splits an endpoint of the form "<protocol>://<address>" into
+// the protocol and address components. Unlike ProtocolAndAddress, it does not
+// default to "tcp" and returns an error if either component is missing.
+func ParseEndpoint(s string) (protocol, address string, err error) {
+	if strings.TrimSpace(s) == "" {
+		return "", "", errors.New("empty endpoint")
+	}
+	parts := strings.SplitN(s, "://", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("endpoint %q must be of the form <protocol>://<address>", s)
+	}
+	protocol, address = parts[0], parts[1]
+	if protocol == "" {
+		return "", "", fmt.Errorf("endpoint %q has no protocol", s)
+	}
+	if address == "" {
+		return "", "", fmt.Errorf("endpoint %q has no address", s)
+	}
+	return protocol, address, nil
+}
+
 // GetFreePort gets a free port from the operating system.
 // Ripped from https://github.com/phayes/freeport.
 // BSD-licensed.
@@ -36,3 +36,32 @@ func TestProtocolAndAddress(t *testing.T) {
 		assert.Equal(t, addr, c.addr)
 	}
 }
+
+func TestParseEndpoint(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		proto    string
+		addr     string
+		err      bool
+	}{
+		{"tcp://127.0.0.1:26659", "tcp", "127.0.0.1:26659", false},
+		{"unix:///tmp/priv.sock", "unix", "/tmp/priv.sock", false},
+		{"", "", "", true},
+		{"   ", "", "", true},
+		{"127.0.0.1:26659", "", "", true},
+		{"tcp:/127.0.0.1:26659", "", "", true},
+		{"://127.0.0.1:26659", "", "", true},
+		{"tcp://", "", "", true},
+	}
+
+	for _, c := range cases {
+		proto, addr, err := ParseEndpoint(c.endpoint)
+		if c.err {
+			assert.Error(t, err, c.endpoint)
+			continue
+		}
+		assert.NoError(t, err, c.endpoint)
+		assert.Equal(t, c.proto, proto)
+		assert.Equal(t, c.addr, addr)
+	}
+}
@@ -33,12 +33,17 @@ package protoio
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 
 	"github.com/gogo/protobuf/proto"
 )
 
+// ErrMsgTooLarge is returned when the length of a message exceeds the max size
+// of the reader. The message is left unread.
+var ErrMsgTooLarge = errors.New("message exceeds max size")
+
 // NewDelimitedReader reads varint-delimited Protobuf messages from a reader.
 // Unlike the gogoproto NewDelimitedReader, this does not buffer the reader,
 // which may cause poor performance but is necessary when only reading single
@@ -78,7 +83,7 @@ func (r *varintReader) ReadMsg(msg proto.Message) (int, error) {
 		return n, fmt.Errorf("invalid out-of-range message length %v", l)
 	}
 	if length > r.maxSize {
-		return n, fmt.Errorf("message exceeds max size (%v > %v)", length, r.maxSize)
+		return n, fmt.Errorf("%w (%v > %v)", ErrMsgTooLarge, length, r.maxSize)
 	}
 
 	if len(r.buf) < length {
@@ -39,6 +39,10 @@ const (
 
 	// 10s is sufficient for most networks.
 	defaultMaxBlockLag = 10 * time.Second
+
+	// A trusting period shorter than 1h leaves too little time to detect and
+	// report misbehavior, which makes the light client trivially attackable.
+	defaultMinTrustingPeriod = 1 * time.Hour
 )
 
 // Option sets a parameter for the light client.
@@ -79,6 +83,18 @@ func PruningSize(h uint16) Option {
 	}
 }
 
+// PruningAge option sets the maximum age of the light blocks that the light
+// client stores, relative to the latest trusted light block: when a light
+// block is trusted, the ones older than the given duration are removed from
+// the store. The light blocks within the trusting period and the latest
+// trusted one are always kept. It applies on top of PruningSize.
+// Default: 0, the light blocks are not pruned by age.
+func PruningAge(d time.Duration) Option {
+	return func(c *Client) {
+		c.pruningAge = d
+	}
+}
+
 // ConfirmationFunction option can be used to prompt to confirm an action. For
 // example, remove newer headers if the light client is being reset with an
 // older header. No confirmation is required by default!
@@ -103,6 +119,20 @@ func MaxRetryAttempts(max uint16) Option {
 	}
 }
 
+// ProviderRetry option wraps the primary and the witnesses to retry the
+// light block fetches failing on a timeout (see provider.NewRetryProvider).
+// By default, a failed fetch is not retried.
+func ProviderRetry(attempts uint16, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.primary = provider.NewRetryProvider(c.primary, attempts, backoff)
+		witnesses := make([]provider.Provider, len(c.witnesses))
+		for i, w := range c.witnesses {
+			witnesses[i] = provider.NewRetryProvider(w, attempts, backoff)
+		}
+		c.witnesses = witnesses
+	}
+}
+
 // MaxClockDrift defines how much new header's time can drift into
 // the future relative to the light clients local time. Default: 10s.
 func MaxClockDrift(d time.Duration) Option {
@@ -125,19 +155,30 @@ func MaxBlockLag(d time.Duration) Option {
 	}
 }
 
+// MinTrustingPeriod sets the lower bound below which the trusting period
+// (see TrustOptions.Period) is rejected as unsafe. It should only be lowered
+// for testing purposes.
+// Default: 1h
+func MinTrustingPeriod(d time.Duration) Option {
+	return func(c *Client) {
+		c.minTrustingPeriod = d
+	}
+}
+
 // Client represents a light client, connected to a single chain, which gets
 // light blocks from a primary provider, verifies them either sequentially or by
 // skipping some and stores them in a trusted store (usually, a local FS).
 //
 // Default verification: SkippingVerification(DefaultTrustLevel)
 type Client struct {
-	chainID          string
-	trustingPeriod   time.Duration // see TrustOptions.Period
-	verificationMode mode
-	trustLevel       tmmath.Fraction
-	maxRetryAttempts uint16 // see MaxRetryAttempts option
-	maxClockDrift    time.Duration
-	maxBlockLag      time.Duration
+	chainID           string
+	trustingPeriod    time.Duration // see TrustOptions.Period
+	minTrustingPeriod time.Duration // see MinTrustingPeriod option
+	verificationMode  mode
+	trustLevel        tmmath.Fraction
+	maxRetryAttempts  uint16 // see MaxRetryAttempts option
+	maxClockDrift     time.Duration
+	maxBlockLag       time.Duration
 
 	// Mutex for locking during changes of the light clients providers
 	providerMutex tmsync.Mutex
@@ -153,6 +194,8 @@ type Client struct {
 
 	// See RemoveNoLongerTrustedHeadersPeriod option
 	pruningSize uint16
+	// See PruningAge option
+	pruningAge time.Duration
 	// See ConfirmationFunction option
 	confirmationFn func(action string) bool
 
@@ -218,20 +261,21 @@ func NewClientFromTrustedStore(
 	options ...Option) (*Client, error) {
 
 	c := &Client{
-		chainID:          chainID,
-		trustingPeriod:   trustingPeriod,
-		verificationMode: skipping,
-		trustLevel:       DefaultTrustLevel,
-		maxRetryAttempts: defaultMaxRetryAttempts,
-		maxClockDrift:    defaultMaxClockDrift,
-		maxBlockLag:      defaultMaxBlockLag,
-		primary:          primary,
-		witnesses:        witnesses,
-		trustedStore:     trustedStore,
-		pruningSize:      defaultPruningSize,
-		confirmationFn:   func(action string) bool { return true },
-		quit:             make(chan struct{}),
-		logger:           log.NewNopLogger(),
+		chainID:           chainID,
+		trustingPeriod:    trustingPeriod,
+		verificationMode:  skipping,
+		trustLevel:        DefaultTrustLevel,
+		maxRetryAttempts:  defaultMaxRetryAttempts,
+		maxClockDrift:     defaultMaxClockDrift,
+		maxBlockLag:       defaultMaxBlockLag,
+		minTrustingPeriod: defaultMinTrustingPeriod,
+		primary:           primary,
+		witnesses:         witnesses,
+		trustedStore:      trustedStore,
+		pruningSize:       defaultPruningSize,
+		confirmationFn:    func(action string) bool { return true },
+		quit:              make(chan struct{}),
+		logger:            log.NewNopLogger(),
 	}
 
 	for _, o := range options {
@@ -256,6 +300,11 @@ func NewClientFromTrustedStore(
 		return nil, err
 	}
 
+	// Validate trusting period.
+	if c.trustingPeriod < c.minTrustingPeriod {
+		return nil, ErrTrustingPeriodTooShort{Period: c.trustingPeriod, Min: c.minTrustingPeriod}
+	}
+
 	if err := c.restoreTrustedLightBlock(); err != nil {
 		return nil, err
 	}
@@ -493,6 +542,41 @@ func (c *Client) VerifyLightBlockAtHeight(ctx context.Context, height int64, now
 	return l, c.verifyLightBlock(ctx, l, now)
 }
 
+// ValidatorSet returns the validator set of the given height, verified against
+// the header of that height: the light block is verified first (see
+// VerifyLightBlockAtHeight), then the hash of its validator set must match the
+// header's ValidatorsHash. The returned set is a copy and can be mutated.
+func (c *Client) ValidatorSet(ctx context.Context, height int64, now time.Time) (*types.ValidatorSet, error) {
+	l, err := c.VerifyLightBlockAtHeight(ctx, height, now)
+	if err != nil {
+		return nil, err
+	}
+	if valsHash := l.ValidatorSet.Hash(); !bytes.Equal(valsHash, l.ValidatorsHash) {
+		return nil, fmt.Errorf("validator set hash %X doesn't match the header's validators hash %X at height %d",
+			valsHash, l.ValidatorsHash, height)
+	}
+	return l.ValidatorSet.Copy(), nil
+}
+
+// ValidatorSetDiff returns the validators that joined, left or changed their
+// voting power between heightA and heightB. Both validator sets are verified
+// against their headers first (see ValidatorSet).
+func (c *Client) ValidatorSetDiff(
+	ctx context.Context,
+	heightA, heightB int64,
+	now time.Time,
+) (types.ValidatorSetDiff, error) {
+	valsA, err := c.ValidatorSet(ctx, heightA, now)
+	if err != nil {
+		return types.ValidatorSetDiff{}, fmt.Errorf("validator set at height %d: %w", heightA, err)
+	}
+	valsB, err := c.ValidatorSet(ctx, heightB, now)
+	if err != nil {
+		return types.ValidatorSetDiff{}, fmt.Errorf("validator set at height %d: %w", heightB, err)
+	}
+	return valsA.Diff(valsB), nil
+}
+
 // VerifyHeader verifies a new header against the trusted state. It returns
 // immediately if newHeader exists in trustedStore (no verification is
 // needed). Else it performs one of the two types of verification:
@@ -849,6 +933,14 @@ func (c *Client) ChainID() string {
 	return c.chainID
 }
 
+// TrustingPeriod returns the trusting period the light client was configured
+// with (see TrustOptions.Period).
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) TrustingPeriod() time.Duration {
+	return c.trustingPeriod
+}
+
 // Primary returns the primary provider.
 //
 // NOTE: provider may be not safe for concurrent access.
@@ -923,9 +1015,47 @@ func (c *Client) updateTrustedLightBlock(l *types.LightBlock) error {
 		c.latestTrustedBlock = l
 	}
 
+	if c.pruningAge > 0 {
+		if err := c.pruneByAge(); err != nil {
+			return fmt.Errorf("prune: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// pruneByAge removes the light blocks older than the pruning age, or the
+// trusting period if longer, before the latest trusted light block.
+func (c *Client) pruneByAge() error {
+	maxAge := c.pruningAge
+	if maxAge < c.trustingPeriod {
+		maxAge = c.trustingPeriod
+	}
+	cutoff := c.latestTrustedBlock.Time.Add(-maxAge)
+
+	// remove the oldest light blocks until reaching one that is fresh enough,
+	// always keeping the latest trusted light block
+	for {
+		height, err := c.trustedStore.FirstLightBlockHeight()
+		if err != nil {
+			return err
+		}
+		if height == -1 || height >= c.latestTrustedBlock.Height {
+			return nil
+		}
+		l, err := c.trustedStore.LightBlock(height)
+		if err != nil {
+			return err
+		}
+		if !l.Time.Before(cutoff) {
+			return nil
+		}
+		if err := c.trustedStore.DeleteLightBlock(height); err != nil {
+			return err
+		}
+	}
+}
+
 // backwards verification (see VerifyHeaderBackwards func in the spec) verifies
 // headers before a trusted header. If a sent header is invalid the primary is
 // replaced with another provider and the operation is repeated.
@@ -796,6 +796,7 @@ func TestClient_BackwardsVerification(t *testing.T) {
 			[]provider.Provider{largeFullNode},
 			dbs.New(dbm.NewMemDB(), chainID),
 			light.Logger(log.TestingLogger()),
+			light.MinTrustingPeriod(time.Minute),
 		)
 		require.NoError(t, err)
 
@@ -909,6 +910,35 @@ func TestClient_NewClientFromTrustedStore(t *testing.T) {
 	assert.EqualValues(t, l1.Height, h.Height)
 }
 
+func TestClient_MinTrustingPeriod(t *testing.T) {
+	db := dbs.New(dbm.NewMemDB(), chainID)
+	err := db.SaveLightBlock(l1)
+	require.NoError(t, err)
+
+	// 1) a 1s trusting period is rejected by default
+	_, err = light.NewClientFromTrustedStore(
+		chainID,
+		1*time.Second,
+		deadNode,
+		[]provider.Provider{deadNode},
+		db,
+	)
+	if assert.Error(t, err) {
+		assert.IsType(t, light.ErrTrustingPeriodTooShort{}, err)
+	}
+
+	// 2) unless the minimum is overridden
+	_, err = light.NewClientFromTrustedStore(
+		chainID,
+		1*time.Second,
+		deadNode,
+		[]provider.Provider{deadNode},
+		db,
+		light.MinTrustingPeriod(time.Second),
+	)
+	assert.NoError(t, err)
+}
+
 func TestClientRemovesWitnessIfItSendsUsIncorrectHeader(t *testing.T) {
 	// different headers hash then primary plus less than 1/3 signed (no fork)
 	h2 := keys.GenSignedHeaderLastBlockID(chainID, 2, bTime.Add(30*time.Minute), nil, vals, vals,
@@ -1032,6 +1062,54 @@ func TestClientPrunesHeadersAndValidatorSets(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestClientPrunesByAge(t *testing.T) {
+	// a light block per minute
+	largeFullNode := mockp.New(genMockNode(chainID, 10, 3, 0, bTime))
+	trustBlock, err := largeFullNode.LightBlock(ctx, 1)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		age         time.Duration
+		firstHeight int64
+	}{
+		// the trusting period is kept
+		{2 * time.Minute, 7},
+		{5 * time.Minute, 5},
+	}
+	for _, tc := range testCases {
+		c, err := light.NewClient(
+			ctx,
+			chainID,
+			light.TrustOptions{
+				Period: 3 * time.Minute,
+				Height: trustBlock.Height,
+				Hash:   trustBlock.Hash(),
+			},
+			largeFullNode,
+			[]provider.Provider{largeFullNode},
+			dbs.New(dbm.NewMemDB(), chainID),
+			light.Logger(log.TestingLogger()),
+			light.MinTrustingPeriod(time.Minute),
+			light.SequentialVerification(),
+			light.PruningAge(tc.age),
+		)
+		require.NoError(t, err)
+
+		for height := int64(2); height <= 10; height++ {
+			_, err = c.VerifyLightBlockAtHeight(ctx, height, bTime.Add(time.Duration(height)*time.Minute))
+			require.NoError(t, err)
+		}
+
+		firstHeight, err := c.FirstTrustedHeight()
+		require.NoError(t, err)
+		assert.Equal(t, tc.firstHeight, firstHeight, tc.age)
+		for height := int64(1); height <= 10; height++ {
+			_, err = c.TrustedLightBlock(height)
+			assert.Equal(t, height < tc.firstHeight, err != nil, height)
+		}
+	}
+}
+
 func TestClientEnsureValidHeadersAndValSets(t *testing.T) {
 	emptyValidatorSet := &types.ValidatorSet{
 		Validators: nil,
@@ -1160,3 +1238,119 @@ func TestClientHandlesContexts(t *testing.T) {
 	require.True(t, errors.Is(err, context.Canceled))
 
 }
+
+func TestClient_ValidatorSet(t *testing.T) {
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{fullNode},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	// a trusted height and a height verified on demand
+	for _, height := range []int64{1, 3} {
+		valSet, err := c.ValidatorSet(ctx, height, bTime.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, vals.Hash(), valSet.Hash())
+	}
+
+	// a set whose hash doesn't match the header is rejected
+	differentVals, _ := types.RandValidatorSet(10, 100)
+	tamperedNode := mockp.New(
+		chainID,
+		headerSet,
+		map[int64]*types.ValidatorSet{
+			1: vals,
+			2: vals,
+			3: differentVals,
+			4: vals,
+		},
+	)
+	c, err = light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		tamperedNode,
+		[]provider.Provider{tamperedNode},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	_, err = c.ValidatorSet(ctx, 3, bTime.Add(2*time.Hour))
+	assert.Error(t, err)
+
+	// a stored light block is returned without verification, so the set is
+	// checked against the header's hash there too
+	trustedStore := dbs.New(dbm.NewMemDB(), chainID)
+	c, err = light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		fullNode,
+		[]provider.Provider{fullNode},
+		trustedStore,
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+	err = trustedStore.SaveLightBlock(&types.LightBlock{SignedHeader: h3, ValidatorSet: differentVals})
+	require.NoError(t, err)
+
+	_, err = c.ValidatorSet(ctx, 3, bTime.Add(2*time.Hour))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "doesn't match the header's validators hash")
+	}
+}
+
+func TestClient_ValidatorSetDiff(t *testing.T) {
+	// a validator joins at height 3
+	newKeys := keys.Extend(1)
+	newVals := newKeys.ToValidators(20, 10)
+	h2 := keys.GenSignedHeaderLastBlockID(chainID, 2, bTime.Add(30*time.Minute), nil, vals, newVals,
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(keys), types.BlockID{Hash: h1.Hash()})
+	h3 := newKeys.GenSignedHeaderLastBlockID(chainID, 3, bTime.Add(1*time.Hour), nil, newVals, newVals,
+		hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(newKeys), types.BlockID{Hash: h2.Hash()})
+	node := mockp.New(
+		chainID,
+		map[int64]*types.SignedHeader{
+			1: h1,
+			2: h2,
+			3: h3,
+		},
+		map[int64]*types.ValidatorSet{
+			1: vals,
+			2: vals,
+			3: newVals,
+			4: newVals,
+		},
+	)
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		node,
+		[]provider.Provider{node},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	diff, err := c.ValidatorSetDiff(ctx, 1, 3, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, newKeys[len(keys)].PubKey().Address(), diff.Added[0].Address)
+	assert.Empty(t, diff.Removed)
+	assert.Empty(t, diff.Updated)
+
+	diff, err = c.ValidatorSetDiff(ctx, 1, 2, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	// the sets must be verified
+	_, err = c.ValidatorSetDiff(ctx, 1, 10, bTime.Add(2*time.Hour))
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/line/ostracon/light/provider"
@@ -104,6 +105,75 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 	return ErrFailedHeaderCrossReferencing
 }
 
+// WitnessConflict is a light block served by a witness which conflicts with
+// the one served by the primary at the same height.
+type WitnessConflict struct {
+	WitnessIndex int
+	Primary      *types.LightBlock
+	Witness      *types.LightBlock
+}
+
+// CompareWitnesses fetches the light block at the given height from the
+// primary and, in parallel, from all the witnesses, and returns the blocks of
+// the witnesses conflicting with the one of the primary. It allows to detect a
+// fork without waiting for the detector to run on the next verification.
+//
+// Neither block of a conflict is verified: the conflicting headers are
+// returned so that the caller can verify them and build evidence. Witnesses
+// failing to provide a block are skipped. An error is returned if the primary
+// fails to provide its block.
+func (c *Client) CompareWitnesses(ctx context.Context, height int64) ([]WitnessConflict, error) {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	if len(c.witnesses) == 0 {
+		return nil, ErrNoWitnesses
+	}
+
+	primaryBlock, err := c.primary.LightBlock(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("primary failed to provide the light block at height %d: %w", height, err)
+	}
+
+	type witnessResponse struct {
+		index int
+		block *types.LightBlock
+		err   error
+	}
+	responses := make(chan witnessResponse, len(c.witnesses))
+	for i, witness := range c.witnesses {
+		go func(i int, witness provider.Provider) {
+			block, err := witness.LightBlock(ctx, height)
+			responses <- witnessResponse{index: i, block: block, err: err}
+		}(i, witness)
+	}
+
+	var conflicts []WitnessConflict
+	for i := 0; i < cap(responses); i++ {
+		res := <-responses
+		switch {
+		case res.err != nil:
+			c.logger.Info("error in light block request to witness", "witness", c.witnesses[res.index],
+				"height", height, "err", res.err)
+		case !bytes.Equal(primaryBlock.Hash(), res.block.Hash()):
+			c.logger.Error("Witness light block conflicts with primary", "witness", c.witnesses[res.index],
+				"height", height, "primaryHash", primaryBlock.Hash(), "witnessHash", res.block.Hash())
+			conflicts = append(conflicts, WitnessConflict{
+				WitnessIndex: res.index,
+				Primary:      primaryBlock,
+				Witness:      res.block,
+			})
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// report the conflicts in the order of the witnesses
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].WitnessIndex < conflicts[j].WitnessIndex })
+	return conflicts, nil
+}
+
 // compareNewHeaderWithWitness takes the verified header from the primary and compares it with a
 // header from a specified witness. The function can return one of three errors:
 //
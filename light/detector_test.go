@@ -451,3 +451,55 @@ func TestClientDivergentTraces4(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, 1, len(c.Witnesses()))
 }
+
+func TestClientCompareWitnesses(t *testing.T) {
+	_, primaryHeaders, primaryVals := genMockNode(chainID, 10, 5, 2, bTime)
+	primary := mockp.New(chainID, primaryHeaders, primaryVals)
+
+	firstBlock, err := primary.LightBlock(ctx, 1)
+	require.NoError(t, err)
+
+	// the second witness serves a conflicting header at height 5
+	_, mockHeaders, mockVals := genMockNode(chainID, 10, 5, 2, bTime)
+	conflictingHeaders := make(map[int64]*types.SignedHeader, len(primaryHeaders))
+	conflictingVals := make(map[int64]*types.ValidatorSet, len(primaryVals))
+	for height := range primaryHeaders {
+		conflictingHeaders[height] = primaryHeaders[height]
+		conflictingVals[height] = primaryVals[height]
+	}
+	conflictingHeaders[5], conflictingVals[5] = mockHeaders[5], mockVals[5]
+	conflicting := mockp.New(chainID, conflictingHeaders, conflictingVals)
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Height: 1,
+			Hash:   firstBlock.Hash(),
+			Period: 4 * time.Hour,
+		},
+		primary,
+		[]provider.Provider{primary.Copy(chainID), conflicting, deadNode},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+
+	conflicts, err := c.CompareWitnesses(ctx, 4)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+
+	conflicts, err = c.CompareWitnesses(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, 1, conflicts[0].WitnessIndex)
+	assert.Equal(t, primaryHeaders[5].Hash(), conflicts[0].Primary.Hash())
+	assert.Equal(t, mockHeaders[5].Hash(), conflicts[0].Witness.Hash())
+
+	// the witnesses are left untouched
+	assert.Len(t, c.Witnesses(), 3)
+
+	// the primary doesn't have the block
+	_, err = c.CompareWitnesses(ctx, 11)
+	assert.Error(t, err)
+}
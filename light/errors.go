@@ -20,6 +20,17 @@ func (e ErrOldHeaderExpired) Error() string {
 	return fmt.Sprintf("old header has expired at %v (now: %v)", e.At, e.Now)
 }
 
+// ErrTrustingPeriodTooShort means the configured trusting period is below the
+// safety minimum (see MinTrustingPeriod option).
+type ErrTrustingPeriodTooShort struct {
+	Period time.Duration
+	Min    time.Duration
+}
+
+func (e ErrTrustingPeriodTooShort) Error() string {
+	return fmt.Sprintf("trusting period %v is shorter than the minimum %v", e.Period, e.Min)
+}
+
 // ErrNewValSetCantBeTrusted means the new validator set cannot be trusted
 // because < 1/3rd (+trustLevel+) of the old validator set has signed.
 type ErrNewValSetCantBeTrusted struct {
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/line/ostracon/types"
+)
+
+// retryProvider retries the failed LightBlock calls of a provider.
+type retryProvider struct {
+	Provider
+	attempts uint16
+	backoff  time.Duration
+}
+
+// NewRetryProvider wraps p to retry a LightBlock call failing with a retriable
+// error (see IsRetriable) up to attempts times in total. It waits backoff
+// before the first retry and doubles the wait before each next one. Once the
+// attempts are exhausted, the error of the last attempt is returned.
+func NewRetryProvider(p Provider, attempts uint16, backoff time.Duration) Provider {
+	if attempts == 0 {
+		attempts = 1
+	}
+	return &retryProvider{Provider: p, attempts: attempts, backoff: backoff}
+}
+
+func (p *retryProvider) String() string {
+	return fmt.Sprintf("retry{%v}", p.Provider)
+}
+
+// LightBlock implements Provider.
+func (p *retryProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	wait := p.backoff
+	for attempt := uint16(1); ; attempt++ {
+		lb, err := p.Provider.LightBlock(ctx, height)
+		if err == nil || attempt == p.attempts || ctx.Err() != nil || !IsRetriable(err) {
+			return lb, err
+		}
+
+		select {
+		case <-time.After(wait):
+			wait *= 2
+		case <-ctx.Done():
+			return nil, err
+		}
+	}
+}
+
+// IsRetriable returns true if err, returned by a provider, is transient: the
+// provider did not respond in time. Errors about the returned data (e.g.
+// ErrBadLightBlock) or its availability (e.g. ErrLightBlockNotFound) won't go
+// away by asking again.
+func IsRetriable(err error) bool {
+	if errors.Is(err, ErrNoResponse) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
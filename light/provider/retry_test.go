@@ -0,0 +1,84 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/line/ostracon/light/provider"
+	"github.com/line/ostracon/types"
+)
+
+// flakyProvider fails with the given errors before returning a light block.
+type flakyProvider struct {
+	errs  []error
+	calls int
+}
+
+func (p *flakyProvider) ChainID() string { return "flaky" }
+
+func (p *flakyProvider) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return nil, p.errs[p.calls-1]
+	}
+	return &types.LightBlock{}, nil
+}
+
+func (p *flakyProvider) ReportEvidence(context.Context, types.Evidence) error { return nil }
+
+func TestRetryProvider(t *testing.T) {
+	ctx := context.Background()
+	badBlock := provider.ErrBadLightBlock{Reason: errors.New("bad")}
+
+	testCases := []struct {
+		name      string
+		errs      []error
+		attempts  uint16
+		wantErr   error
+		wantCalls int
+	}{
+		{"succeeds on the third attempt",
+			[]error{provider.ErrNoResponse, context.DeadlineExceeded}, 3, nil, 3},
+		{"attempts exhausted",
+			[]error{provider.ErrNoResponse, provider.ErrNoResponse, context.DeadlineExceeded}, 3,
+			context.DeadlineExceeded, 3},
+		{"bad light block is not retried", []error{badBlock}, 3, badBlock, 1},
+		{"missing light block is not retried",
+			[]error{provider.ErrLightBlockNotFound}, 3, provider.ErrLightBlockNotFound, 1},
+		{"zero attempts means one", []error{provider.ErrNoResponse}, 0, provider.ErrNoResponse, 1},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			flaky := &flakyProvider{errs: tc.errs}
+			p := provider.NewRetryProvider(flaky, tc.attempts, time.Millisecond)
+			assert.Equal(t, "flaky", p.ChainID())
+
+			lb, err := p.LightBlock(ctx, 1)
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, err)
+				assert.Nil(t, lb)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, lb)
+			}
+			assert.Equal(t, tc.wantCalls, flaky.calls)
+		})
+	}
+}
+
+func TestRetryProviderContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	flaky := &flakyProvider{errs: []error{provider.ErrNoResponse, provider.ErrNoResponse}}
+	p := provider.NewRetryProvider(flaky, 3, time.Hour)
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err := p.LightBlock(ctx, 1)
+	assert.Equal(t, provider.ErrNoResponse, err)
+	assert.Equal(t, 1, flaky.calls)
+}
@@ -0,0 +1,82 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGzipHandler(t *testing.T) {
+	large := bytes.Repeat([]byte(`{"validator":"0123456789"}`), 200)
+	small := []byte(`{"result":{}}`)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		// written in chunks crossing the threshold
+		for i := 0; i < len(large); i += 100 {
+			_, err := w.Write(large[i : i+100])
+			require.NoError(t, err)
+		}
+	})
+	mux.HandleFunc("/small", func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write(small)
+		require.NoError(t, err)
+	})
+	handler := gzipHandler(mux, DefaultGzipMinSize)
+
+	get := func(path, acceptEncoding string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+	body := func(resp *http.Response) []byte {
+		defer resp.Body.Close()
+		bz, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return bz
+	}
+
+	// a gzip-accepting client receives compressed bytes
+	for _, acceptEncoding := range []string{"gzip", "deflate, gzip;q=0.8", "*", "*;q=0.5, deflate", "gzip; q=1"} {
+		resp := get("/large", acceptEncoding)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		compressed := body(resp)
+		assert.Less(t, len(compressed), len(large))
+
+		gz, err := gzip.NewReader(bytes.NewReader(compressed))
+		require.NoError(t, err)
+		decompressed, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		assert.Equal(t, large, decompressed)
+	}
+
+	// a non-accepting client receives plain bytes
+	for _, acceptEncoding := range []string{
+		"", "deflate", "identity", "gzip;q=0", "gzip;q=0.0, deflate", "*;q=0", "*, gzip;q=0", "gzip;q=invalid",
+	} {
+		resp := get("/large", acceptEncoding)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		assert.Equal(t, large, body(resp))
+	}
+
+	// a small response stays plain
+	resp := get("/small", "gzip")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	assert.Equal(t, small, body(resp))
+}
@@ -21,6 +21,12 @@ type Proxy struct {
 	Client   *lrpc.Client
 	Logger   log.Logger
 	Listener net.Listener
+	// The light client whose trust status is served on /trust_status. The
+	// endpoint is disabled if nil.
+	LightClient TrustSource
+	// The responses of at least GzipMinSize bytes are gzipped for the clients
+	// accepting it (Accept-Encoding). 0 disables the compression.
+	GzipMinSize int
 }
 
 // NewProxy creates the struct used to run an HTTP server for serving light
@@ -38,10 +44,12 @@ func NewProxy(
 	}
 
 	return &Proxy{
-		Addr:   listenAddr,
-		Config: config,
-		Client: lrpc.NewClient(rpcClient, lightClient, opts...),
-		Logger: logger,
+		Addr:        listenAddr,
+		Config:      config,
+		Client:      lrpc.NewClient(rpcClient, lightClient, opts...),
+		Logger:      logger,
+		LightClient: lightClient,
+		GzipMinSize: DefaultGzipMinSize,
 	}, nil
 }
 
@@ -58,7 +66,7 @@ func (p *Proxy) ListenAndServe() error {
 
 	return rpcserver.Serve(
 		listener,
-		mux,
+		p.handler(mux),
 		p.Logger,
 		p.Config,
 	)
@@ -76,7 +84,7 @@ func (p *Proxy) ListenAndServeTLS(certFile, keyFile string) error {
 
 	return rpcserver.ServeTLS(
 		listener,
-		mux,
+		p.handler(mux),
 		certFile,
 		keyFile,
 		p.Logger,
@@ -84,6 +92,13 @@ func (p *Proxy) ListenAndServeTLS(certFile, keyFile string) error {
 	)
 }
 
+func (p *Proxy) handler(mux *http.ServeMux) http.Handler {
+	if p.GzipMinSize <= 0 {
+		return mux
+	}
+	return gzipHandler(mux, p.GzipMinSize)
+}
+
 func (p *Proxy) listen() (net.Listener, *http.ServeMux, error) {
 	mux := http.NewServeMux()
 
@@ -101,18 +116,24 @@ func (p *Proxy) listen() (net.Listener, *http.ServeMux, error) {
 			}
 		}),
 		rpcserver.ReadLimit(p.Config.MaxBodyBytes),
+		rpcserver.SubscriptionLimits(p.Config.MaxSubscriptionsPerConnection, p.Config.MaxSubscriptions),
 	)
 	wm.SetLogger(wmLogger)
 	mux.HandleFunc("/websocket", wm.WebsocketHandler)
 
-	// 3) Start a client.
+	// 3) Serve the trust status of the light client.
+	if p.LightClient != nil {
+		mux.HandleFunc("/trust_status", trustStatusHandler(p.LightClient))
+	}
+
+	// 4) Start a client.
 	if !p.Client.IsRunning() {
 		if err := p.Client.Start(); err != nil {
 			return nil, mux, fmt.Errorf("can't start client: %w", err)
 		}
 	}
 
-	// 4) Start listening for new connections.
+	// 5) Start listening for new connections.
 	listener, err := rpcserver.Listen(p.Addr, p.Config)
 	if err != nil {
 		return nil, mux, err
@@ -0,0 +1,115 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/line/ostracon/light/provider"
+	"github.com/line/ostracon/types"
+)
+
+// providerHealthTimeout bounds the time a provider has to return its latest
+// light block to be reported healthy.
+const providerHealthTimeout = 5 * time.Second
+
+// TrustSource is the part of light.Client the trust status is read from.
+type TrustSource interface {
+	LastTrustedHeight() (int64, error)
+	TrustedLightBlock(height int64) (*types.LightBlock, error)
+	TrustingPeriod() time.Duration
+	Primary() provider.Provider
+	Witnesses() []provider.Provider
+}
+
+// TrustStatus is the response of the /trust_status endpoint. Once Expired,
+// the light client can't verify new headers and needs to be re-initialized
+// with new trust options.
+type TrustStatus struct {
+	// 0 if there's no trusted block
+	LatestTrustedHeight int64     `json:"latest_trusted_height"`
+	LatestTrustedTime   time.Time `json:"latest_trusted_time"`
+	// the time left until the latest trusted block leaves the trusting
+	// period, 0 once expired
+	TrustPeriodRemaining time.Duration    `json:"trust_period_remaining"`
+	Expired              bool             `json:"expired"`
+	Primary              ProviderStatus   `json:"primary"`
+	Witnesses            []ProviderStatus `json:"witnesses"`
+}
+
+// ProviderStatus is the health of a provider: whether it returned its latest
+// light block in time.
+type ProviderStatus struct {
+	Provider string `json:"provider"`
+	Healthy  bool   `json:"healthy"`
+	Error    string `json:"error,omitempty"`
+}
+
+// GetTrustStatus returns the trust status of the light client at the given
+// time, checking the health of its providers concurrently.
+func GetTrustStatus(ctx context.Context, lc TrustSource, now time.Time) (*TrustStatus, error) {
+	status := &TrustStatus{Expired: true}
+
+	height, err := lc.LastTrustedHeight()
+	if err != nil {
+		return nil, fmt.Errorf("can't get the last trusted height: %w", err)
+	}
+	if height > 0 {
+		lb, err := lc.TrustedLightBlock(height)
+		if err != nil {
+			return nil, fmt.Errorf("can't get the trusted light block #%d: %w", height, err)
+		}
+		status.LatestTrustedHeight = height
+		status.LatestTrustedTime = lb.Time
+		if remaining := lb.Time.Add(lc.TrustingPeriod()).Sub(now); remaining > 0 {
+			status.TrustPeriodRemaining = remaining
+			status.Expired = false
+		}
+	}
+
+	witnesses := lc.Witnesses()
+	status.Witnesses = make([]ProviderStatus, len(witnesses))
+	var wg sync.WaitGroup
+	check := func(p provider.Provider, ps *ProviderStatus) {
+		defer wg.Done()
+		*ps = providerStatus(ctx, p)
+	}
+	wg.Add(1 + len(witnesses))
+	go check(lc.Primary(), &status.Primary)
+	for i, w := range witnesses {
+		go check(w, &status.Witnesses[i])
+	}
+	wg.Wait()
+
+	return status, nil
+}
+
+func providerStatus(ctx context.Context, p provider.Provider) ProviderStatus {
+	ctx, cancel := context.WithTimeout(ctx, providerHealthTimeout)
+	defer cancel()
+
+	ps := ProviderStatus{Provider: fmt.Sprint(p), Healthy: true}
+	if _, err := p.LightBlock(ctx, 0); err != nil {
+		ps.Healthy = false
+		ps.Error = err.Error()
+	}
+	return ps
+}
+
+// trustStatusHandler serves the trust status of the light client as JSON.
+func trustStatusHandler(lc TrustSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, err := GetTrustStatus(r.Context(), lc, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
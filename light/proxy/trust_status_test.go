@@ -0,0 +1,109 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/line/ostracon/light/provider"
+	mockp "github.com/line/ostracon/light/provider/mock"
+	"github.com/line/ostracon/types"
+)
+
+// mockTrustSource is a light client with a single trusted block.
+type mockTrustSource struct {
+	lb             *types.LightBlock
+	trustingPeriod time.Duration
+	primary        provider.Provider
+	witnesses      []provider.Provider
+}
+
+func (m *mockTrustSource) LastTrustedHeight() (int64, error) {
+	if m.lb == nil {
+		return -1, nil
+	}
+	return m.lb.Height, nil
+}
+
+func (m *mockTrustSource) TrustedLightBlock(height int64) (*types.LightBlock, error) {
+	if m.lb == nil || height != m.lb.Height {
+		return nil, errors.New("light block not found")
+	}
+	return m.lb, nil
+}
+
+func (m *mockTrustSource) TrustingPeriod() time.Duration  { return m.trustingPeriod }
+func (m *mockTrustSource) Primary() provider.Provider     { return m.primary }
+func (m *mockTrustSource) Witnesses() []provider.Provider { return m.witnesses }
+
+// healthyProvider returns the same light block for any height.
+type healthyProvider struct {
+	provider.Provider
+	lb *types.LightBlock
+}
+
+func (p healthyProvider) String() string { return "healthy" }
+
+func (p healthyProvider) LightBlock(context.Context, int64) (*types.LightBlock, error) {
+	return p.lb, nil
+}
+
+func TestTrustStatusHandler(t *testing.T) {
+	const trustingPeriod = 2 * time.Hour
+	lb := &types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &types.Header{
+			ChainID: "test",
+			Height:  42,
+			// a minute before the trusting period expires
+			Time: time.Now().Add(-trustingPeriod + time.Minute),
+		}},
+	}
+	lc := &mockTrustSource{
+		lb:             lb,
+		trustingPeriod: trustingPeriod,
+		primary:        healthyProvider{lb: lb},
+		witnesses:      []provider.Provider{mockp.NewDeadMock("test"), healthyProvider{lb: lb}},
+	}
+
+	get := func() TrustStatus {
+		rec := httptest.NewRecorder()
+		trustStatusHandler(lc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/trust_status", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var status TrustStatus
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		return status
+	}
+
+	status := get()
+	assert.EqualValues(t, 42, status.LatestTrustedHeight)
+	assert.True(t, lb.Time.Equal(status.LatestTrustedTime))
+	assert.False(t, status.Expired)
+	assert.Greater(t, status.TrustPeriodRemaining, time.Duration(0))
+	assert.LessOrEqual(t, status.TrustPeriodRemaining, time.Minute)
+	assert.Equal(t, ProviderStatus{Provider: "healthy", Healthy: true}, status.Primary)
+	require.Len(t, status.Witnesses, 2)
+	assert.Equal(t, "deadMock", status.Witnesses[0].Provider)
+	assert.False(t, status.Witnesses[0].Healthy)
+	assert.NotEmpty(t, status.Witnesses[0].Error)
+	assert.True(t, status.Witnesses[1].Healthy)
+
+	// past the trusting period
+	lb.Time = lb.Time.Add(-2 * time.Minute)
+	status = get()
+	assert.True(t, status.Expired)
+	assert.Zero(t, status.TrustPeriodRemaining)
+
+	// no trusted block
+	lc.lb = nil
+	status = get()
+	assert.Zero(t, status.LatestTrustedHeight)
+	assert.True(t, status.Expired)
+}
@@ -1,9 +1,11 @@
 package mempool
 
 import (
+	"bufio"
 	"container/list"
 	"crypto/sha256"
 	"fmt"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -72,6 +74,9 @@ type CListMempool struct {
 	// This reduces the pressure on the proxyApp.
 	cache txCache
 
+	// orders the txs reaped for a block
+	orderingPolicy OrderingPolicy
+
 	logger log.Logger
 
 	metrics *Metrics
@@ -97,13 +102,14 @@ func NewCListMempool(
 	options ...CListMempoolOption,
 ) *CListMempool {
 	mempool := &CListMempool{
-		config:       config,
-		proxyAppConn: proxyAppConn,
-		txs:          clist.New(),
-		height:       height,
-		chReqCheckTx: make(chan *requestCheckTxAsync, config.Size),
-		logger:       log.NewNopLogger(),
-		metrics:      NopMetrics(),
+		config:         config,
+		proxyAppConn:   proxyAppConn,
+		txs:            clist.New(),
+		height:         height,
+		chReqCheckTx:   make(chan *requestCheckTxAsync, config.Size),
+		orderingPolicy: FIFOOrdering{},
+		logger:         log.NewNopLogger(),
+		metrics:        NopMetrics(),
 	}
 	if config.CacheSize > 0 {
 		mempool.cache = newMapTxCache(config.CacheSize)
@@ -174,6 +180,72 @@ func (mem *CListMempool) CloseWAL() {
 	mem.wal = nil
 }
 
+// CompactWAL rewrites the WAL file so that it only holds the txs currently in
+// the mempool. It is a no-op if the WAL is not enabled.
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) CompactWAL() error {
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+
+	if mem.wal == nil {
+		return nil
+	}
+
+	// Let the txs being checked reach the mempool, so they are kept.
+	if err := mem.FlushAppConn(); err != nil {
+		return err
+	}
+
+	walFile := mem.wal.Path
+	tmpFile := walFile + ".compact"
+	if err := mem.writeTxsTo(tmpFile); err != nil {
+		os.Remove(tmpFile) // nolint:errcheck // ignore error
+		return err
+	}
+
+	// The old WAL stays open until the compacted one replaces it, so the WAL
+	// is never disabled by a failed compaction.
+	if err := os.Rename(tmpFile, walFile); err != nil {
+		os.Remove(tmpFile) // nolint:errcheck // ignore error
+		return err
+	}
+	// The WAL is reopened after the rename, as its file handle still points to
+	// the old file.
+	af, err := auto.OpenAutoFile(walFile)
+	if err != nil {
+		// keep the old handle, which reopens the file by its path when it's
+		// closed periodically
+		return fmt.Errorf("can't open autofile %s: %w", walFile, err)
+	}
+	if err := mem.wal.Close(); err != nil {
+		mem.logger.Error("Error closing WAL", "err", err)
+	}
+	mem.wal = af
+	return nil
+}
+
+// writeTxsTo writes the txs of the mempool to the given file, in the WAL
+// format.
+func (mem *CListMempool) writeTxsTo(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if _, err := w.Write(append([]byte(memTx.tx), newline...)); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) Lock() {
 	mem.updateMtx.Lock()
@@ -325,6 +397,7 @@ func (mem *CListMempool) prepareCheckTx(tx types.Tx, txInfo TxInfo) error {
 
 	if mem.preCheck != nil {
 		if err := mem.preCheck(tx); err != nil {
+			mem.metrics.RejectedTxs.With("reason", rejectReason(err)).Add(1)
 			return ErrPreCheck{err}
 		}
 	}
@@ -570,6 +643,7 @@ func (mem *CListMempool) resCbRecheck(req *ocabci.Request, res *ocabci.Response)
 				return
 			}
 			r.CheckTx.MempoolError = postCheckErr.Error()
+			mem.metrics.RejectedTxs.With("reason", rejectReason(postCheckErr)).Add(1)
 		}
 		celem := e.(*clist.CElement)
 		// Tx became invalidated due to newly committed block.
@@ -612,25 +686,24 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	// txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
 	txs := make([]types.Tx, 0, mem.txs.Len())
 	protoTxs := tmproto.Data{}
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-
-		protoTxs.Txs = append(protoTxs.Txs, memTx.tx)
+	mem.reapOrder(func(tx types.Tx, gasWanted int64) bool {
+		protoTxs.Txs = append(protoTxs.Txs, tx)
 		// Check total size requirement
 		if maxBytes > -1 && int64(protoTxs.Size()) > maxBytes {
-			return txs
+			return false
 		}
 		// Check total gas requirement.
 		// If maxGas is negative, skip this check.
 		// Since newTotalGas < masGas, which
 		// must be non-negative, it follows that this won't overflow.
-		newTotalGas := totalGas + memTx.gasWanted
+		newTotalGas := totalGas + gasWanted
 		if maxGas > -1 && newTotalGas > maxGas {
-			return txs
+			return false
 		}
 		totalGas = newTotalGas
-		txs = append(txs, memTx.tx)
-	}
+		txs = append(txs, tx)
+		return true
+	})
 	return txs
 }
 
@@ -650,28 +723,74 @@ func (mem *CListMempool) ReapMaxBytesMaxGasMaxTxs(maxBytes, maxGas, maxTxs int64
 	// txs := make([]types.Tx, 0, tmmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
 	txs := make([]types.Tx, 0, mem.txs.Len())
 	protoTxs := tmproto.Data{}
-	for e := mem.txs.Front(); e != nil && len(txs) < int(maxTxs); e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-
-		protoTxs.Txs = append(protoTxs.Txs, memTx.tx)
+	mem.reapOrder(func(tx types.Tx, gasWanted int64) bool {
+		if len(txs) >= int(maxTxs) {
+			return false
+		}
+		protoTxs.Txs = append(protoTxs.Txs, tx)
 		// Check total size requirement
 		if maxBytes > -1 && int64(protoTxs.Size()) > maxBytes {
-			return txs
+			return false
 		}
 		// Check total gas requirement.
 		// If maxGas is negative, skip this check.
 		// Since newTotalGas < masGas, which
 		// must be non-negative, it follows that this won't overflow.
-		newTotalGas := totalGas + memTx.gasWanted
+		newTotalGas := totalGas + gasWanted
 		if maxGas > -1 && newTotalGas > maxGas {
-			return txs
+			return false
 		}
 		totalGas = newTotalGas
-		txs = append(txs, memTx.tx)
-	}
+		txs = append(txs, tx)
+		return true
+	})
 	return txs
 }
 
+// reapOrder calls fn on the txs of the mempool in the order given by the
+// ordering policy, until fn returns false.
+//
+// Under the default FIFO policy the list is walked directly, so a reap stops
+// as soon as the block is full. Other policies need to see every tx, so only
+// they pay for a copy of the mempool.
+func (mem *CListMempool) reapOrder(fn func(tx types.Tx, gasWanted int64) bool) {
+	if _, ok := mem.orderingPolicy.(FIFOOrdering); ok {
+		for e := mem.txs.Front(); e != nil; e = e.Next() {
+			memTx := e.Value.(*mempoolTx)
+			if !fn(memTx.tx, memTx.gasWanted) {
+				return
+			}
+		}
+		return
+	}
+
+	pending := make([]PendingTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		pending = append(pending, PendingTx{Tx: memTx.tx, GasWanted: memTx.gasWanted, Height: memTx.Height()})
+	}
+	for _, ptx := range mem.orderingPolicy.Order(pending) {
+		if !fn(ptx.Tx, ptx.GasWanted) {
+			return
+		}
+	}
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *CListMempool) GetByHash(hash []byte) (types.Tx, bool) {
+	var txKey [TxKeySize]byte
+	if len(hash) != TxKeySize {
+		return nil, false
+	}
+	copy(txKey[:], hash)
+
+	e, ok := mem.txsMap.Load(txKey)
+	if !ok {
+		return nil, false
+	}
+	return e.(*clist.CElement).Value.(*mempoolTx).tx, true
+}
+
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	mem.updateMtx.RLock()
@@ -16,6 +16,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/gogo/protobuf/proto"
 	gogotypes "github.com/gogo/protobuf/types"
 	"github.com/stretchr/testify/assert"
@@ -224,6 +225,33 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+func TestMempoolGetByHash(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	pending := types.Tx("pending=1")
+	committed := types.Tx("committed=1")
+	for _, tx := range []types.Tx{pending, committed} {
+		_, err := mempool.CheckTxSync(tx, TxInfo{})
+		require.NoError(t, err)
+	}
+	err := mempool.Update(newTestBlock(1, []types.Tx{committed}), abciResponses(1, ocabci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+
+	tx, ok := mempool.GetByHash(pending.Hash())
+	assert.True(t, ok)
+	assert.Equal(t, pending, tx)
+
+	_, ok = mempool.GetByHash(committed.Hash())
+	assert.False(t, ok)
+	_, ok = mempool.GetByHash(types.Tx("absent=1").Hash())
+	assert.False(t, ok)
+	_, ok = mempool.GetByHash([]byte("short"))
+	assert.False(t, ok)
+}
+
 func TestMempool_KeepInvalidTxsInCache(t *testing.T) {
 	app := counter.NewApplication(true)
 	cc := proxy.NewLocalClientCreator(app)
@@ -757,3 +785,168 @@ func TestTxMempoolPostCheckError(t *testing.T) {
 		})
 	}
 }
+
+// reasonCounter records additions per "reason" label value.
+type reasonCounter struct {
+	reason string
+	counts map[string]float64
+}
+
+func (c *reasonCounter) With(labelValues ...string) metrics.Counter {
+	for i := 0; i+1 < len(labelValues); i += 2 {
+		if labelValues[i] == "reason" {
+			return &reasonCounter{reason: labelValues[i+1], counts: c.counts}
+		}
+	}
+	return c
+}
+
+func (c *reasonCounter) Add(delta float64) {
+	c.counts[c.reason] += delta
+}
+
+func TestMempoolRejectedTxsMetric(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	rejected := &reasonCounter{counts: map[string]float64{}}
+	mempool.metrics = NopMetrics()
+	mempool.metrics.RejectedTxs = rejected
+
+	// size-based rejection by the pre check
+	mempool.preCheck = PreCheckMaxBytes(10)
+	_, err := mempool.CheckTxSync(tmrand.Bytes(20), TxInfo{})
+	require.Error(t, err)
+	require.True(t, IsPreCheckError(err))
+	assert.Equal(t, float64(1), rejected.counts[RejectReasonMaxBytes])
+	assert.Zero(t, rejected.counts[RejectReasonMaxGas])
+
+	// gas-based rejection by the post check on recheck
+	mempool.preCheck = nil
+	tx := types.Tx{1}
+	_, err = mempool.CheckTxSync(tx, TxInfo{})
+	require.NoError(t, err)
+	require.Equal(t, 1, mempool.Size())
+
+	mempool.postCheck = PostCheckMaxGas(1)
+	req := ocabci.ToRequestCheckTx(abci.RequestCheckTx{Tx: tx, Type: abci.CheckTxType_Recheck})
+	res := ocabci.ToResponseCheckTx(ocabci.ResponseCheckTx{Code: ocabci.CodeTypeOK, GasWanted: 2})
+	mempool.resCbRecheck(req, res)
+	assert.Equal(t, 0, mempool.Size())
+	assert.Equal(t, float64(1), rejected.counts[RejectReasonMaxBytes])
+	assert.Equal(t, float64(1), rejected.counts[RejectReasonMaxGas])
+}
+
+func TestMempoolGasPriceOrdering(t *testing.T) {
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// the first byte of each tx is its gas price
+	txs := types.Txs{{3, 0}, {1, 1}, {5, 2}, {2, 3}, {5, 4}}
+	for _, tx := range txs {
+		_, err := mempool.CheckTxSync(tx, TxInfo{})
+		require.NoError(t, err)
+	}
+
+	// FIFO by default
+	assert.Equal(t, txs, mempool.ReapMaxBytesMaxGas(-1, -1))
+
+	mempool.orderingPolicy = NewGasPriceOrdering(func(tx types.Tx) int64 { return int64(tx[0]) })
+	// higher-priced txs first, txs of the same price in arrival order
+	assert.Equal(t, types.Txs{txs[2], txs[4], txs[0], txs[3], txs[1]}, mempool.ReapMaxBytesMaxGas(-1, -1))
+	// each tx wants 1 gas
+	assert.Equal(t, types.Txs{txs[2], txs[4], txs[0]}, mempool.ReapMaxBytesMaxGas(-1, 3))
+	assert.Equal(t, types.Txs{txs[2], txs[4]}, mempool.ReapMaxBytesMaxGasMaxTxs(-1, -1, 2))
+
+	// reaping doesn't reorder the mempool itself
+	assert.Equal(t, txs, mempool.ReapMaxTxs(-1))
+}
+
+func TestMempoolCompactWAL(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "mempool-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(rootDir)
+
+	wcfg := cfg.DefaultConfig()
+	wcfg.Mempool.RootDir = rootDir
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, wcfg)
+	defer cleanup()
+
+	// a no-op without WAL
+	require.NoError(t, mempool.CompactWAL())
+
+	require.NoError(t, mempool.InitWAL())
+	defer mempool.CloseWAL()
+	walFilepath := mempool.wal.Path
+
+	for _, tx := range []string{"foo", "bar", "baz"} {
+		_, err = mempool.CheckTxSync(types.Tx(tx), TxInfo{})
+		require.NoError(t, err)
+	}
+	wal, err := ioutil.ReadFile(walFilepath)
+	require.NoError(t, err)
+	require.Equal(t, "foo\nbar\nbaz\n", string(wal))
+
+	// commit foo and baz
+	mempool.Lock()
+	err = mempool.Update(newTestBlock(1, []types.Tx{types.Tx("foo"), types.Tx("baz")}),
+		abciResponses(2, ocabci.CodeTypeOK), nil, nil)
+	mempool.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, 1, mempool.Size())
+
+	require.NoError(t, mempool.CompactWAL())
+	wal, err = ioutil.ReadFile(walFilepath)
+	require.NoError(t, err)
+	assert.Equal(t, "bar\n", string(wal))
+
+	// the WAL is still written to after compaction
+	_, err = mempool.CheckTxSync(types.Tx("qux"), TxInfo{})
+	require.NoError(t, err)
+	wal, err = ioutil.ReadFile(walFilepath)
+	require.NoError(t, err)
+	assert.Equal(t, "bar\nqux\n", string(wal))
+
+	// no leftover file
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(walFilepath), "*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{walFilepath}, files)
+}
+
+func TestMempoolCompactWALFailureKeepsWAL(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "mempool-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(rootDir)
+
+	wcfg := cfg.DefaultConfig()
+	wcfg.Mempool.RootDir = rootDir
+	app := kvstore.NewApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, wcfg)
+	defer cleanup()
+
+	require.NoError(t, mempool.InitWAL())
+	defer mempool.CloseWAL()
+	walFilepath := mempool.wal.Path
+
+	_, err = mempool.CheckTxSync(types.Tx("foo"), TxInfo{})
+	require.NoError(t, err)
+
+	// the compacted file can't be written
+	require.NoError(t, os.Mkdir(walFilepath+".compact", 0700))
+	require.Error(t, mempool.CompactWAL())
+
+	// the WAL is still enabled and written to
+	require.NotNil(t, mempool.wal)
+	_, err = mempool.CheckTxSync(types.Tx("bar"), TxInfo{})
+	require.NoError(t, err)
+	wal, err := ioutil.ReadFile(walFilepath)
+	require.NoError(t, err)
+	assert.Equal(t, "foo\nbar\n", string(wal))
+}
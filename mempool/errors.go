@@ -38,6 +38,51 @@ func (e ErrMempoolIsFull) Error() string {
 		e.txsBytes, e.maxTxsBytes)
 }
 
+// ErrPreCheckMaxBytes is returned by PreCheckMaxBytes when the tx exceeds the
+// maximum data size of a block
+type ErrPreCheckMaxBytes struct {
+	Max    int64
+	Actual int64
+}
+
+func (e ErrPreCheckMaxBytes) Error() string {
+	return fmt.Sprintf("tx size is too big: %d, max: %d", e.Actual, e.Max)
+}
+
+// ErrPostCheckMaxGas is returned by PostCheckMaxGas when the tx wants more gas
+// than available for a block
+type ErrPostCheckMaxGas struct {
+	Max    int64
+	Wanted int64
+}
+
+func (e ErrPostCheckMaxGas) Error() string {
+	return fmt.Sprintf("gas wanted %d is greater than max gas %d", e.Wanted, e.Max)
+}
+
+// Reasons reported by the RejectedTxs metric
+const (
+	RejectReasonMaxBytes = "max_bytes"
+	RejectReasonMaxGas   = "max_gas"
+	RejectReasonOther    = "other"
+)
+
+// rejectReason maps a pre/post check error to the reason reported by the
+// RejectedTxs metric.
+func rejectReason(err error) string {
+	if e, ok := err.(ErrPreCheck); ok {
+		err = e.Reason
+	}
+	switch err.(type) {
+	case ErrPreCheckMaxBytes:
+		return RejectReasonMaxBytes
+	case ErrPostCheckMaxGas:
+		return RejectReasonMaxGas
+	default:
+		return RejectReasonOther
+	}
+}
+
 // ErrPreCheck is returned when tx is too big
 type ErrPreCheck struct {
 	Reason error
@@ -35,6 +35,10 @@ type Mempool interface {
 	// transactions (~ all available transactions).
 	ReapMaxTxs(max int) types.Txs
 
+	// GetByHash returns the pending transaction with the given hash (see
+	// types.Tx.Hash), if any.
+	GetByHash(hash []byte) (types.Tx, bool)
+
 	// Lock locks the mempool. The consensus must be able to hold lock to safely update.
 	Lock()
 
@@ -81,6 +85,11 @@ type Mempool interface {
 	// CloseWAL closes and discards the underlying WAL file.
 	// Any further writes will not be relayed to disk.
 	CloseWAL()
+
+	// CompactWAL rewrites the WAL file so that it only holds the txs currently
+	// in the mempool, dropping the ones committed since they were written.
+	// Safe for concurrent use by multiple goroutines.
+	CompactWAL() error
 }
 
 //--------------------------------------------------------------------------------
@@ -113,8 +122,7 @@ func PreCheckMaxBytes(maxBytes int64) PreCheckFunc {
 		txSize := types.ComputeProtoSizeForTxs([]types.Tx{tx})
 
 		if txSize > maxBytes {
-			return fmt.Errorf("tx size is too big: %d, max: %d",
-				txSize, maxBytes)
+			return ErrPreCheckMaxBytes{Max: maxBytes, Actual: txSize}
 		}
 		return nil
 	}
@@ -132,8 +140,7 @@ func PostCheckMaxGas(maxGas int64) PostCheckFunc {
 				res.GasWanted)
 		}
 		if res.GasWanted > maxGas {
-			return fmt.Errorf("gas wanted %d is greater than max gas %d",
-				res.GasWanted, maxGas)
+			return ErrPostCheckMaxGas{Max: maxGas, Wanted: res.GasWanted}
 		}
 		return nil
 	}
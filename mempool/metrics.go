@@ -22,6 +22,9 @@ type Metrics struct {
 	TxSizeBytes metrics.Histogram
 	// Number of failed transactions.
 	FailedTxs metrics.Counter
+	// Number of transactions rejected by the pre/post check filters, labeled
+	// by reason.
+	RejectedTxs metrics.Counter
 	// Number of times transactions are rechecked in the mempool.
 	RecheckCount metrics.Counter
 	// Time of recheck transactions in the mempool.
@@ -56,6 +59,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "failed_txs",
 			Help:      "Number of failed transactions.",
 		}, labels).With(labelsAndValues...),
+		RejectedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_txs",
+			Help:      "Number of transactions rejected by the pre/post check filters, labeled by reason.",
+		}, append(labels, "reason")).With(labelsAndValues...),
 		RecheckCount: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -77,6 +86,7 @@ func NopMetrics() *Metrics {
 		Size:         discard.NewGauge(),
 		TxSizeBytes:  discard.NewHistogram(),
 		FailedTxs:    discard.NewCounter(),
+		RejectedTxs:  discard.NewCounter(),
 		RecheckCount: discard.NewCounter(),
 		RecheckTime:  discard.NewGauge(),
 	}
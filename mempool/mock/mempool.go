@@ -25,6 +25,7 @@ func (Mempool) CheckTxAsync(_ types.Tx, _ mempl.TxInfo, _ func(error), _ func(*o
 func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs          { return types.Txs{} }
 func (Mempool) ReapMaxBytesMaxGasMaxTxs(_, _, _ int64) types.Txs { return types.Txs{} }
 func (Mempool) ReapMaxTxs(n int) types.Txs                       { return types.Txs{} }
+func (Mempool) GetByHash(_ []byte) (types.Tx, bool)              { return nil, false }
 func (Mempool) Update(
 	_ *types.Block,
 	_ []*abci.ResponseDeliverTx,
@@ -42,5 +43,6 @@ func (Mempool) TxsBytes() int64               { return 0 }
 func (Mempool) TxsFront() *clist.CElement    { return nil }
 func (Mempool) TxsWaitChan() <-chan struct{} { return nil }
 
-func (Mempool) InitWAL() error { return nil }
-func (Mempool) CloseWAL()      {}
+func (Mempool) InitWAL() error    { return nil }
+func (Mempool) CloseWAL()         {}
+func (Mempool) CompactWAL() error { return nil }
@@ -0,0 +1,73 @@
+package mempool
+
+import (
+	"sort"
+
+	"github.com/line/ostracon/types"
+)
+
+// PendingTx is a transaction waiting in the mempool, as seen by an
+// OrderingPolicy.
+type PendingTx struct {
+	Tx        types.Tx
+	GasWanted int64 // amount of gas the tx states it will require
+	Height    int64 // height the tx was last validated at
+}
+
+// OrderingPolicy decides the order in which the mempool reaps transactions
+// for a block (ReapMaxBytesMaxGas and ReapMaxBytesMaxGasMaxTxs).
+type OrderingPolicy interface {
+	// Order is given the pending txs in arrival order and returns them in
+	// the order they should be reaped. It may reorder txs in place.
+	Order(txs []PendingTx) []PendingTx
+}
+
+// WithOrderingPolicy sets the policy used to order the reaped txs. The
+// default is FIFOOrdering.
+func WithOrderingPolicy(policy OrderingPolicy) CListMempoolOption {
+	return func(mem *CListMempool) { mem.orderingPolicy = policy }
+}
+
+// FIFOOrdering reaps txs in the order they arrived in the mempool.
+type FIFOOrdering struct{}
+
+var _ OrderingPolicy = FIFOOrdering{}
+
+// Order implements OrderingPolicy.
+func (FIFOOrdering) Order(txs []PendingTx) []PendingTx {
+	return txs
+}
+
+// GasPriceOrdering reaps txs by descending gas price, and txs of the same gas
+// price in the order they arrived. The gas price of a tx is app specific, so
+// it is extracted by the given function (e.g. by decoding the tx's fee).
+type GasPriceOrdering struct {
+	gasPrice func(tx types.Tx) int64
+}
+
+var _ OrderingPolicy = GasPriceOrdering{}
+
+// NewGasPriceOrdering returns a GasPriceOrdering using gasPrice to get the
+// gas price of a tx.
+func NewGasPriceOrdering(gasPrice func(tx types.Tx) int64) GasPriceOrdering {
+	return GasPriceOrdering{gasPrice: gasPrice}
+}
+
+// Order implements OrderingPolicy.
+func (o GasPriceOrdering) Order(txs []PendingTx) []PendingTx {
+	// compute the prices up front, as the sort moves the txs around
+	keyed := make([]struct {
+		tx    PendingTx
+		price int64
+	}, len(txs))
+	for i := range txs {
+		keyed[i].tx, keyed[i].price = txs[i], o.gasPrice(txs[i].Tx)
+	}
+	sort.SliceStable(keyed, func(i, j int) bool {
+		return keyed[i].price > keyed[j].price
+	})
+	for i := range keyed {
+		txs[i] = keyed[i].tx
+	}
+	return txs
+}
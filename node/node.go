@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	cfg "github.com/line/ostracon/config"
 	cs "github.com/line/ostracon/consensus"
 	"github.com/line/ostracon/crypto"
+	"github.com/line/ostracon/crypto/vrf"
 	"github.com/line/ostracon/evidence"
 	tmjson "github.com/line/ostracon/libs/json"
 	"github.com/line/ostracon/libs/log"
@@ -82,7 +84,18 @@ type GenesisDocProvider func() (*types.GenesisDoc, error)
 // the GenesisDoc from the config.GenesisFile() on the filesystem.
 func DefaultGenesisDocProviderFunc(config *cfg.Config) GenesisDocProvider {
 	return func() (*types.GenesisDoc, error) {
-		return types.GenesisDocFromFile(config.GenesisFile())
+		// the genesis file is streamed as it may be very large
+		genDocFile := config.GenesisFile()
+		f, err := os.Open(genDocFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
+		}
+		defer f.Close()
+		genDoc, err := types.GenesisDocFromReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
+		}
+		return genDoc, nil
 	}
 }
 
@@ -338,11 +351,13 @@ func doHandshake(
 	genDoc *types.GenesisDoc,
 	eventBus types.BlockEventPublisher,
 	proxyApp proxy.AppConns,
+	infoTimeout time.Duration,
 	consensusLogger log.Logger) error {
 
 	handshaker := cs.NewHandshaker(stateStore, state, blockStore, genDoc)
 	handshaker.SetLogger(consensusLogger)
 	handshaker.SetEventBus(eventBus)
+	handshaker.SetInfoTimeout(infoTimeout)
 	if err := handshaker.Handshake(proxyApp); err != nil {
 		return fmt.Errorf("error during handshake: %v", err)
 	}
@@ -706,6 +721,11 @@ func NewNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option) (*Node, error) {
 
+	// Every node needs VRF to elect and verify block proposers.
+	if err := vrf.Available(); err != nil {
+		return nil, fmt.Errorf("cannot create node: %w; check the VRF build options", err)
+	}
+
 	blockStore, stateDB, err := initDBs(config, dbProvider)
 	if err != nil {
 		return nil, err
@@ -749,6 +769,9 @@ func NewNode(config *cfg.Config,
 		}
 	}
 
+	if err := types.SelfTestPrivValidator(privValidator); err != nil {
+		return nil, fmt.Errorf("private validator self-test failed: %w", err)
+	}
 	pubKey, err := privValidator.GetPubKey()
 	if err != nil {
 		return nil, fmt.Errorf("can't get pubkey: %w", err)
@@ -765,7 +788,8 @@ func NewNode(config *cfg.Config,
 	// and replays any blocks as necessary to sync ostracon with the app.
 	consensusLogger := logger.With("module", "consensus")
 	if !stateSync {
-		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp, consensusLogger); err != nil {
+		if err := doHandshake(stateStore, state, blockStore, genDoc, eventBus, proxyApp,
+			config.ABCIHandshakeTimeout, consensusLogger); err != nil {
 			return nil, err
 		}
 
@@ -835,6 +859,9 @@ func NewNode(config *cfg.Config,
 		config.P2P.StatesyncRecvBufSize)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
 
+	// Cache the resolutions of the hosts of the peer addresses.
+	p2p.SetDNSCacheTTL(config.P2P.DNSCacheTTL)
+
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
 	if err != nil {
 		return nil, err
@@ -1114,6 +1141,9 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
 	config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
 	config.MaxOpenConnections = n.config.RPC.MaxOpenConnections
+	config.ListenBacklog = n.config.RPC.ListenBacklog
+	config.MaxSubscriptionsPerConnection = n.config.RPC.MaxSubscriptionsPerClient
+	config.MaxSubscriptions = n.config.RPC.MaxSubscriptions
 	config.ReadTimeout = n.config.RPC.ReadTimeout
 	config.WriteTimeout = n.config.RPC.WriteTimeout
 	config.IdleTimeout = n.config.RPC.IdleTimeout
@@ -1139,10 +1169,14 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 			}),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 			rpcserver.WriteChanCapacity(n.config.RPC.WebSocketWriteBufferSize),
+			rpcserver.SubscriptionLimits(config.MaxSubscriptionsPerConnection, config.MaxSubscriptions),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
 		rpcserver.RegisterRPCFuncs(mux, rpccore.Routes, rpcLogger)
+		if n.config.RPC.PprofOnRPC {
+			rpcserver.RegisterDebugHandlers(mux, n.config.RPC.PprofOnRPCAuthToken)
+		}
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config,
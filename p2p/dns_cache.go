@@ -0,0 +1,87 @@
+package p2p
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// defaultDNSCache resolves the hosts of the peer addresses (see
+// NewNetAddressString). It's disabled unless SetDNSCacheTTL is called.
+var defaultDNSCache = NewDNSCache(0)
+
+// SetDNSCacheTTL sets the time the IPs the hosts of the peer addresses resolve
+// to are cached for. 0 disables the cache.
+func SetDNSCacheTTL(ttl time.Duration) {
+	defaultDNSCache.SetTTL(ttl)
+}
+
+type dnsCacheEntry struct {
+	ips     []net.IP
+	expires time.Time
+}
+
+// DNSCache caches the IPs hosts resolve to for a TTL, reducing the load of the
+// resolver when the same peers are dialed repeatedly. If the resolution of a
+// host fails, the last IPs it resolved to are returned, even if expired, so
+// that transient DNS failures don't make the peers flap.
+type DNSCache struct {
+	mtx     sync.Mutex
+	ttl     time.Duration
+	entries map[string]dnsCacheEntry
+
+	// replaced in the tests
+	lookupIP func(host string) ([]net.IP, error)
+	now      func() time.Time
+}
+
+// NewDNSCache returns a DNSCache caching the IPs for the given TTL. A TTL of 0
+// disables the cache.
+func NewDNSCache(ttl time.Duration) *DNSCache {
+	return &DNSCache{
+		ttl:      ttl,
+		entries:  make(map[string]dnsCacheEntry),
+		lookupIP: net.LookupIP,
+		now:      time.Now,
+	}
+}
+
+// SetTTL sets the TTL of the entries cached from now on. 0 disables the cache
+// and drops the cached entries.
+func (c *DNSCache) SetTTL(ttl time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.ttl = ttl
+	if ttl <= 0 {
+		c.entries = make(map[string]dnsCacheEntry)
+	}
+}
+
+// LookupIP returns the IPs of the host, as net.LookupIP, from the cache if they
+// haven't expired.
+func (c *DNSCache) LookupIP(host string) ([]net.IP, error) {
+	c.mtx.Lock()
+	ttl := c.ttl
+	entry, cached := c.entries[host]
+	c.mtx.Unlock()
+
+	if ttl <= 0 {
+		return c.lookupIP(host)
+	}
+	if cached && c.now().Before(entry.expires) {
+		return entry.ips, nil
+	}
+
+	ips, err := c.lookupIP(host)
+	if err != nil {
+		if cached {
+			return entry.ips, nil
+		}
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	c.entries[host] = dnsCacheEntry{ips: ips, expires: c.now().Add(ttl)}
+	c.mtx.Unlock()
+	return ips, nil
+}
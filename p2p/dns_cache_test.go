@@ -0,0 +1,67 @@
+package p2p
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSCache(t *testing.T) {
+	var (
+		now     = time.Now()
+		lookups int
+		ip      = net.ParseIP("10.0.0.1")
+		fail    bool
+	)
+	c := NewDNSCache(time.Minute)
+	c.now = func() time.Time { return now }
+	c.lookupIP = func(host string) ([]net.IP, error) {
+		lookups++
+		if fail {
+			return nil, errors.New("no such host")
+		}
+		return []net.IP{ip}, nil
+	}
+
+	// repeated resolutions within the TTL hit the cache
+	for i := 0; i < 3; i++ {
+		ips, err := c.LookupIP("peer.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []net.IP{ip}, ips)
+		now = now.Add(10 * time.Second)
+	}
+	assert.Equal(t, 1, lookups)
+
+	// an expired entry re-resolves
+	now = now.Add(time.Minute)
+	ip = net.ParseIP("10.0.0.2")
+	ips, err := c.LookupIP("peer.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []net.IP{ip}, ips)
+	assert.Equal(t, 2, lookups)
+
+	// a failed resolution serves the last good result
+	now = now.Add(2 * time.Minute)
+	fail = true
+	ips, err = c.LookupIP("peer.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []net.IP{net.ParseIP("10.0.0.2")}, ips)
+	assert.Equal(t, 3, lookups)
+
+	// unless there's none
+	_, err = c.LookupIP("other.example.com")
+	assert.Error(t, err)
+
+	// a TTL of 0 disables the cache
+	fail = false
+	c.SetTTL(0)
+	for i := 0; i < 2; i++ {
+		_, err = c.LookupIP("peer.example.com")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 6, lookups)
+}
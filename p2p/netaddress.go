@@ -65,7 +65,8 @@ func NewNetAddress(id ID, addr net.Addr) *NetAddress {
 
 // NewNetAddressString returns a new NetAddress using the provided address in
 // the form of "ID@IP:Port".
-// Also resolves the host if host is not an IP.
+// Also resolves the host if host is not an IP, through the DNS cache (see
+// SetDNSCacheTTL).
 // Errors are of type ErrNetAddressXxx where Xxx is in (NoID, Invalid, Lookup)
 func NewNetAddressString(addr string) (*NetAddress, error) {
 	addrWithoutProtocol := removeProtocolIfDefined(addr)
@@ -94,7 +95,7 @@ func NewNetAddressString(addr string) (*NetAddress, error) {
 
 	ip := net.ParseIP(host)
 	if ip == nil {
-		ips, err := net.LookupIP(host)
+		ips, err := defaultDNSCache.LookupIP(host)
 		if err != nil {
 			return nil, ErrNetAddressLookup{host, err}
 		}
@@ -21,6 +21,14 @@ func SignerDialerEndpointTimeoutReadWrite(timeout time.Duration) SignerServiceEn
 	return func(ss *SignerDialerEndpoint) { ss.timeoutReadWrite = timeout }
 }
 
+// SignerDialerEndpointMaxMsgSize sets the max size of the requests read from
+// client processes. The connection is dropped on a larger request.
+//
+// Default: 10KB
+func SignerDialerEndpointMaxMsgSize(size int) SignerServiceEndpointOption {
+	return func(ss *SignerDialerEndpoint) { ss.maxMsgSize = size }
+}
+
 // SignerDialerEndpointConnRetries sets the amount of attempted retries to
 // acceptNewConnection.
 func SignerDialerEndpointConnRetries(retries int) SignerServiceEndpointOption {
@@ -61,6 +69,7 @@ func NewSignerDialerEndpoint(
 
 	sd.BaseService = *service.NewBaseService(logger, "SignerDialerEndpoint", sd)
 	sd.signerEndpoint.timeoutReadWrite = defaultTimeoutReadWriteSeconds * time.Second
+	sd.signerEndpoint.maxMsgSize = defaultMaxMsgSize
 
 	for _, optionFunc := range options {
 		optionFunc(sd)
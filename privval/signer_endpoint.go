@@ -1,6 +1,7 @@
 package privval
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"time"
@@ -13,6 +14,11 @@ import (
 
 const (
 	defaultTimeoutReadWriteSeconds = 5
+
+	// defaultMaxMsgSize is the max size of a message read from the other end.
+	// The largest legitimate messages, sign requests and responses, are well
+	// below 1KB.
+	defaultMaxMsgSize = 1024 * 10
 )
 
 type signerEndpoint struct {
@@ -22,6 +28,7 @@ type signerEndpoint struct {
 	conn    net.Conn
 
 	timeoutReadWrite time.Duration
+	maxMsgSize       int
 }
 
 // Close closes the underlying net.Conn.
@@ -94,9 +101,18 @@ func (se *signerEndpoint) ReadMessage() (msg ocprivvalproto.Message, err error)
 	if err != nil {
 		return
 	}
-	const maxRemoteSignerMsgSize = 1024 * 10
-	protoReader := protoio.NewDelimitedReader(se.conn, maxRemoteSignerMsgSize)
+	maxMsgSize := se.maxMsgSize
+	if maxMsgSize <= 0 {
+		maxMsgSize = defaultMaxMsgSize
+	}
+	protoReader := protoio.NewDelimitedReader(se.conn, maxMsgSize)
 	_, err = protoReader.ReadMsg(&msg)
+	if errors.Is(err, protoio.ErrMsgTooLarge) {
+		// the message is left unread, so the connection can't be read anymore
+		se.Logger.Error("Dropping [read]: message too large", "obj", se, "err", err)
+		se.dropConnection()
+		return
+	}
 	if _, ok := err.(timeoutError); ok {
 		if err != nil {
 			err = fmt.Errorf("%v: %w", err, ErrReadTimeout)
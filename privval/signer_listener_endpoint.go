@@ -24,6 +24,15 @@ func SignerListenerEndpointTimeoutReadWrite(timeout time.Duration) SignerListene
 	return func(sl *SignerListenerEndpoint) { sl.signerEndpoint.timeoutReadWrite = timeout }
 }
 
+// SignerListenerEndpointMaxMsgSize sets the max size of the responses read
+// from external signing processes. The connection is dropped on a larger
+// response.
+//
+// Default: 10KB
+func SignerListenerEndpointMaxMsgSize(size int) SignerListenerEndpointOption {
+	return func(sl *SignerListenerEndpoint) { sl.signerEndpoint.maxMsgSize = size }
+}
+
 // SignerListenerEndpoint listens for an external process to dial in and keeps
 // the connection alive by dropping and reconnecting.
 //
@@ -56,6 +65,7 @@ func NewSignerListenerEndpoint(
 
 	sl.BaseService = *service.NewBaseService(logger, "SignerListenerEndpoint", sl)
 	sl.signerEndpoint.timeoutReadWrite = defaultTimeoutReadWriteSeconds * time.Second
+	sl.signerEndpoint.maxMsgSize = defaultMaxMsgSize
 
 	for _, optionFunc := range options {
 		optionFunc(sl)
@@ -7,11 +7,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 
 	"github.com/line/ostracon/crypto/ed25519"
 	"github.com/line/ostracon/libs/log"
 	tmnet "github.com/line/ostracon/libs/net"
+	"github.com/line/ostracon/libs/protoio"
 	tmrand "github.com/line/ostracon/libs/rand"
+	ocprivvalproto "github.com/line/ostracon/proto/ostracon/privval"
 	"github.com/line/ostracon/types"
 )
 
@@ -213,3 +216,28 @@ func getMockEndpoints(
 
 	return listenerEndpoint, dialerEndpoint
 }
+
+func TestSignerEndpointMaxMsgSize(t *testing.T) {
+	conn, remote := net.Pipe()
+	defer remote.Close()
+	endpoint := NewSignerDialerEndpoint(log.TestingLogger(), nil, SignerDialerEndpointMaxMsgSize(64))
+	endpoint.SetConnection(conn)
+
+	write := func(msg ocprivvalproto.Message) {
+		go func() {
+			_, _ = protoio.NewDelimitedWriter(remote).WriteMsg(&msg)
+		}()
+	}
+
+	// a small request is read
+	write(mustWrapMsg(&privvalproto.PubKeyRequest{ChainId: "chain"}))
+	msg, err := endpoint.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "chain", msg.GetPubKeyRequest().ChainId)
+
+	// an oversized request is rejected and drops the connection
+	write(mustWrapMsg(&privvalproto.PubKeyRequest{ChainId: tmrand.Str(64)}))
+	_, err = endpoint.ReadMessage()
+	require.ErrorIs(t, err, protoio.ErrMsgTooLarge)
+	assert.False(t, endpoint.IsConnected())
+}
@@ -125,6 +125,14 @@ type State struct {
 	AppHash []byte `protobuf:"bytes,13,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
 	// the VRF Proof value generated by the last Proposer
 	LastProofHash []byte `protobuf:"bytes,1000,opt,name=last_proof_hash,json=lastProofHash,proto3" json:"last_proof_hash,omitempty"`
+	// Ostracon specific consensus parameters, set at genesis
+	VRFMessageDomain           string `protobuf:"bytes,1001,opt,name=vrf_message_domain,json=vrfMessageDomain,proto3" json:"vrf_message_domain,omitempty"`
+	VRFSeedMixing              bool   `protobuf:"varint,1002,opt,name=vrf_seed_mixing,json=vrfSeedMixing,proto3" json:"vrf_seed_mixing,omitempty"`
+	ProposerSelectionPrecision uint32 `protobuf:"varint,1003,opt,name=proposer_selection_precision,json=proposerSelectionPrecision,proto3" json:"proposer_selection_precision,omitempty"`
+	MaxEvidencePerBlock        int64  `protobuf:"varint,1004,opt,name=max_evidence_per_block,json=maxEvidencePerBlock,proto3" json:"max_evidence_per_block,omitempty"`
+	ProposerTieBreak           int32  `protobuf:"varint,1005,opt,name=proposer_tie_break,json=proposerTieBreak,proto3" json:"proposer_tie_break,omitempty"`
+	MaxValidators              int64  `protobuf:"varint,1006,opt,name=max_validators,json=maxValidators,proto3" json:"max_validators,omitempty"`
+	CommitTimestampTolerance   int64  `protobuf:"varint,1007,opt,name=commit_timestamp_tolerance,json=commitTimestampTolerance,proto3" json:"commit_timestamp_tolerance,omitempty"`
 }
 
 func (m *State) Reset()         { *m = State{} }
@@ -265,6 +273,55 @@ func (m *State) GetLastProofHash() []byte {
 	return nil
 }
 
+func (m *State) GetVRFMessageDomain() string {
+	if m != nil {
+		return m.VRFMessageDomain
+	}
+	return ""
+}
+
+func (m *State) GetVRFSeedMixing() bool {
+	if m != nil {
+		return m.VRFSeedMixing
+	}
+	return false
+}
+
+func (m *State) GetProposerSelectionPrecision() uint32 {
+	if m != nil {
+		return m.ProposerSelectionPrecision
+	}
+	return 0
+}
+
+func (m *State) GetMaxEvidencePerBlock() int64 {
+	if m != nil {
+		return m.MaxEvidencePerBlock
+	}
+	return 0
+}
+
+func (m *State) GetProposerTieBreak() int32 {
+	if m != nil {
+		return m.ProposerTieBreak
+	}
+	return 0
+}
+
+func (m *State) GetMaxValidators() int64 {
+	if m != nil {
+		return m.MaxValidators
+	}
+	return 0
+}
+
+func (m *State) GetCommitTimestampTolerance() int64 {
+	if m != nil {
+		return m.CommitTimestampTolerance
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ABCIResponses)(nil), "ostracon.state.ABCIResponses")
 	proto.RegisterType((*State)(nil), "ostracon.state.State")
@@ -273,51 +330,65 @@ func init() {
 func init() { proto.RegisterFile("ostracon/state/types.proto", fileDescriptor_898987a4421067cd) }
 
 var fileDescriptor_898987a4421067cd = []byte{
-	// 703 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x4f, 0x6f, 0xd3, 0x30,
-	0x18, 0xc6, 0x1b, 0xba, 0xad, 0x9d, 0xb3, 0xb6, 0x10, 0x38, 0x64, 0x1d, 0xa4, 0x65, 0xfc, 0xab,
-	0x90, 0x48, 0xa5, 0x71, 0xe2, 0x82, 0x44, 0x5a, 0xb4, 0x55, 0x9a, 0xd0, 0x94, 0x4d, 0x3b, 0x70,
-	0x89, 0x9c, 0xc4, 0x4b, 0x2c, 0xd2, 0x38, 0x8a, 0xdd, 0x69, 0x7c, 0x05, 0x4e, 0xfb, 0x58, 0x3b,
-	0xee, 0x88, 0x38, 0x0c, 0xd4, 0x5d, 0xf8, 0x18, 0xc8, 0x76, 0x9c, 0x66, 0x2b, 0x93, 0x76, 0x73,
-	0xde, 0xe7, 0x79, 0x7f, 0x7a, 0x6c, 0xbf, 0x31, 0xe8, 0x12, 0xca, 0x72, 0x18, 0x90, 0x74, 0x48,
-	0x19, 0x64, 0x68, 0xc8, 0xbe, 0x67, 0x88, 0xda, 0x59, 0x4e, 0x18, 0x31, 0xda, 0x4a, 0xb3, 0x85,
-	0xd6, 0x7d, 0x12, 0x91, 0x88, 0x08, 0x69, 0xc8, 0x57, 0xd2, 0xd5, 0xdd, 0x2c, 0x09, 0xd0, 0x0f,
-	0x70, 0x15, 0xd0, 0x5d, 0xc0, 0x45, 0xf5, 0x86, 0xd6, 0x67, 0x28, 0x0d, 0x51, 0x3e, 0xc5, 0x29,
-	0x2b, 0xd4, 0x53, 0x98, 0xe0, 0x10, 0x32, 0x92, 0x17, 0x8e, 0x67, 0x4b, 0x8e, 0x0c, 0xe6, 0x70,
-	0xaa, 0x00, 0x4f, 0x97, 0xe4, 0x2a, 0xde, 0xaa, 0xa8, 0xa7, 0x28, 0xa7, 0x58, 0x85, 0x28, 0xf4,
-	0x5e, 0x44, 0x48, 0x94, 0xa0, 0xa1, 0xf8, 0xf2, 0x67, 0x27, 0x43, 0x86, 0xa7, 0x88, 0x32, 0x38,
-	0xcd, 0xfe, 0x83, 0x5f, 0x3a, 0x9a, 0xee, 0x56, 0x45, 0xbd, 0xbd, 0xed, 0xed, 0x5f, 0x1a, 0x68,
-	0x7d, 0x72, 0x46, 0x13, 0x17, 0xd1, 0x8c, 0xa4, 0x14, 0x51, 0x63, 0x04, 0xf4, 0x10, 0x25, 0xf8,
-	0x14, 0xe5, 0x1e, 0x3b, 0xa3, 0xa6, 0xd6, 0xaf, 0x0f, 0xf4, 0x9d, 0x6d, 0x7b, 0x01, 0xb1, 0x39,
-	0xc4, 0x56, 0x0d, 0x63, 0xe9, 0x3d, 0x3a, 0x73, 0x41, 0xa8, 0x96, 0xd4, 0xf8, 0x08, 0xd6, 0x51,
-	0x1a, 0x7a, 0x7e, 0x42, 0x82, 0x6f, 0xe6, 0x83, 0xbe, 0x36, 0xd0, 0x77, 0x9e, 0xdf, 0x89, 0xf8,
-	0x9c, 0x86, 0x0e, 0x37, 0xba, 0x4d, 0x54, 0xac, 0x8c, 0x31, 0xd0, 0x7d, 0x14, 0xe1, 0xb4, 0x20,
-	0xd4, 0x05, 0xe1, 0xc5, 0x9d, 0x04, 0x87, 0x7b, 0x25, 0x03, 0xf8, 0xe5, 0x7a, 0xfb, 0x47, 0x03,
-	0xac, 0x1e, 0xf2, 0xf3, 0x30, 0x3e, 0x80, 0x46, 0x71, 0xb2, 0xa6, 0x26, 0x58, 0x9b, 0x55, 0x96,
-	0x38, 0x33, 0xfb, 0x58, 0x1a, 0x9c, 0x95, 0x8b, 0xab, 0x5e, 0xcd, 0x55, 0x7e, 0xe3, 0x35, 0x68,
-	0x06, 0x31, 0xc4, 0xa9, 0x87, 0x43, 0xb1, 0x93, 0x75, 0x47, 0x9f, 0x5f, 0xf5, 0x1a, 0x23, 0x5e,
-	0x9b, 0x8c, 0xdd, 0x86, 0x10, 0x27, 0xa1, 0xf1, 0x0a, 0xb4, 0x71, 0x8a, 0x19, 0x86, 0x89, 0x17,
-	0x23, 0x1c, 0xc5, 0xcc, 0x6c, 0xf7, 0xb5, 0x41, 0xdd, 0x6d, 0x15, 0xd5, 0x3d, 0x51, 0x34, 0xde,
-	0x82, 0x47, 0x09, 0xa4, 0x4c, 0x6e, 0x4c, 0x39, 0xeb, 0xc2, 0xd9, 0xe1, 0x82, 0x48, 0x5e, 0x78,
-	0x5d, 0xd0, 0xaa, 0x78, 0x71, 0x68, 0xae, 0x2c, 0x67, 0x97, 0x97, 0x29, 0xba, 0x26, 0x63, 0xe7,
-	0x31, 0xcf, 0x3e, 0xbf, 0xea, 0xe9, 0xfb, 0x0a, 0x35, 0x19, 0xbb, 0x7a, 0xc9, 0x9d, 0x84, 0xc6,
-	0x3e, 0xe8, 0x54, 0x98, 0x7c, 0x92, 0xcc, 0x55, 0x41, 0xed, 0xda, 0x72, 0xcc, 0x6c, 0x35, 0x66,
-	0xf6, 0x91, 0x1a, 0x33, 0xa7, 0xc9, 0xb1, 0xe7, 0xbf, 0x7b, 0x9a, 0xdb, 0x2a, 0x59, 0x5c, 0x35,
-	0x76, 0x41, 0x27, 0x45, 0x67, 0xcc, 0x2b, 0xff, 0x07, 0x6a, 0xae, 0x09, 0x9a, 0xb5, 0x9c, 0xf1,
-	0x58, 0x79, 0x0e, 0x11, 0x73, 0xdb, 0xbc, 0xad, 0xac, 0xf0, 0x81, 0x01, 0x15, 0x46, 0xe3, 0x5e,
-	0x8c, 0x4a, 0x07, 0x0f, 0x22, 0xb6, 0x55, 0x81, 0x34, 0xef, 0x17, 0x84, 0xb7, 0x55, 0x82, 0x8c,
-	0x80, 0x25, 0x40, 0xf2, 0x66, 0x2a, 0x3c, 0x2f, 0x88, 0x61, 0x1a, 0xa1, 0xd0, 0x5c, 0x17, 0x97,
-	0xb5, 0xc5, 0x5d, 0xf2, 0x9e, 0x16, 0xdd, 0x23, 0x69, 0x31, 0x5c, 0xf0, 0x30, 0xe0, 0x73, 0x99,
-	0xd2, 0x19, 0xf5, 0xe4, 0x4b, 0x60, 0x82, 0xe5, 0xbf, 0x40, 0xc6, 0x19, 0x29, 0xe7, 0x81, 0x30,
-	0x16, 0xf3, 0xd7, 0x09, 0x6e, 0x96, 0x8d, 0x2f, 0xe0, 0x65, 0x35, 0xd8, 0x6d, 0x7e, 0x19, 0x4f,
-	0x17, 0xf1, 0xfa, 0x8b, 0x78, 0xb7, 0xf8, 0x2a, 0xa3, 0x1a, 0xc4, 0x1c, 0xd1, 0x59, 0xc2, 0xa8,
-	0x17, 0x43, 0x1a, 0x9b, 0x1b, 0x7d, 0x6d, 0xb0, 0x21, 0x07, 0xd1, 0x95, 0xf5, 0x3d, 0x48, 0x63,
-	0x63, 0x13, 0x34, 0x61, 0x96, 0x49, 0x4b, 0x4b, 0x58, 0x1a, 0x30, 0xcb, 0x84, 0xf4, 0xa6, 0x38,
-	0xf8, 0x2c, 0x27, 0xe4, 0x44, 0x3a, 0xfe, 0x36, 0x84, 0x45, 0x8c, 0xca, 0x01, 0x2f, 0x73, 0xa3,
-	0xb3, 0x7b, 0x31, 0xb7, 0xb4, 0xcb, 0xb9, 0xa5, 0xfd, 0x99, 0x5b, 0xda, 0xf9, 0xb5, 0x55, 0xbb,
-	0xbc, 0xb6, 0x6a, 0x3f, 0xaf, 0xad, 0xda, 0xd7, 0x77, 0x11, 0x66, 0xf1, 0xcc, 0xb7, 0x03, 0x32,
-	0x1d, 0x26, 0x38, 0x45, 0xc3, 0xf2, 0x29, 0x96, 0x0f, 0xf8, 0xcd, 0x67, 0xdf, 0x5f, 0x13, 0xd5,
-	0xf7, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0xe7, 0x82, 0xd1, 0xeb, 0x0f, 0x06, 0x00, 0x00,
+	// 920 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x95, 0xcf, 0x6f, 0xdb, 0x36,
+	0x14, 0xc7, 0xa3, 0xa5, 0xa9, 0x1d, 0xba, 0xb6, 0x53, 0xb5, 0x18, 0x14, 0xb7, 0xb3, 0xbd, 0x6c,
+	0xeb, 0x82, 0x01, 0x95, 0x81, 0x6e, 0x97, 0x0d, 0xd8, 0x80, 0xca, 0xee, 0x0f, 0x03, 0xed, 0x10,
+	0x28, 0x41, 0x0e, 0xbb, 0x08, 0xb4, 0xf4, 0x2c, 0x13, 0x95, 0x48, 0x81, 0x64, 0x0c, 0xef, 0xbf,
+	0xe8, 0x79, 0x7f, 0x51, 0x8f, 0x3d, 0x0e, 0x3b, 0x78, 0x83, 0x73, 0xd9, 0xef, 0xfd, 0x0b, 0x03,
+	0x49, 0x49, 0x96, 0xe3, 0x16, 0xc8, 0x8d, 0x7a, 0xdf, 0xef, 0xfb, 0xe8, 0x91, 0x7c, 0x24, 0x51,
+	0x87, 0x09, 0xc9, 0x71, 0xc8, 0xe8, 0x40, 0x48, 0x2c, 0x61, 0x20, 0x7f, 0xcc, 0x40, 0xb8, 0x19,
+	0x67, 0x92, 0xd9, 0xad, 0x42, 0x73, 0xb5, 0xd6, 0xb9, 0x1b, 0xb3, 0x98, 0x69, 0x69, 0xa0, 0x46,
+	0xc6, 0xd5, 0x39, 0x2c, 0x09, 0x78, 0x12, 0x92, 0x2a, 0xa0, 0xb3, 0x86, 0xeb, 0xe8, 0x86, 0xd6,
+	0x97, 0x40, 0x23, 0xe0, 0x29, 0xa1, 0x32, 0x57, 0xe7, 0x38, 0x21, 0x11, 0x96, 0x8c, 0xe7, 0x8e,
+	0x8f, 0xb6, 0x1c, 0x19, 0xe6, 0x38, 0x2d, 0x00, 0xf7, 0xb7, 0xe4, 0x2a, 0xbe, 0x5b, 0x51, 0xe7,
+	0xc0, 0x05, 0x61, 0x74, 0x43, 0xef, 0xc5, 0x8c, 0xc5, 0x09, 0x0c, 0xf4, 0xd7, 0xe4, 0x62, 0x3a,
+	0x90, 0x24, 0x05, 0x21, 0x71, 0x9a, 0xbd, 0x03, 0xbf, 0xb5, 0x34, 0x9d, 0x7b, 0x15, 0xf5, 0xea,
+	0xb4, 0x8f, 0x7e, 0xb1, 0x50, 0xf3, 0xb1, 0x37, 0x1c, 0xfb, 0x20, 0x32, 0x46, 0x05, 0x08, 0x7b,
+	0x88, 0x1a, 0x11, 0x24, 0x64, 0x0e, 0x3c, 0x90, 0x0b, 0xe1, 0x58, 0xfd, 0xdd, 0xe3, 0xc6, 0xa3,
+	0x23, 0x77, 0x0d, 0x71, 0x15, 0xc4, 0x2d, 0x12, 0x46, 0xc6, 0x7b, 0xb6, 0xf0, 0x51, 0x54, 0x0c,
+	0x85, 0xfd, 0x1d, 0xda, 0x07, 0x1a, 0x05, 0x93, 0x84, 0x85, 0xaf, 0x9c, 0x0f, 0xfa, 0xd6, 0x71,
+	0xe3, 0xd1, 0xc7, 0xef, 0x45, 0x3c, 0xa1, 0x91, 0xa7, 0x8c, 0x7e, 0x1d, 0xf2, 0x91, 0x3d, 0x42,
+	0x8d, 0x09, 0xc4, 0x84, 0xe6, 0x84, 0x5d, 0x4d, 0xf8, 0xe4, 0xbd, 0x04, 0x4f, 0x79, 0x0d, 0x03,
+	0x4d, 0xca, 0xf1, 0xd1, 0x4f, 0x08, 0xed, 0x9d, 0xaa, 0xf5, 0xb0, 0xbf, 0x46, 0xb5, 0x7c, 0x65,
+	0x1d, 0x4b, 0xb3, 0x0e, 0xab, 0x2c, 0xbd, 0x66, 0xee, 0xb9, 0x31, 0x78, 0x37, 0xde, 0x2c, 0x7b,
+	0x3b, 0x7e, 0xe1, 0xb7, 0x1f, 0xa0, 0x7a, 0x38, 0xc3, 0x84, 0x06, 0x24, 0xd2, 0x33, 0xd9, 0xf7,
+	0x1a, 0xab, 0x65, 0xaf, 0x36, 0x54, 0xb1, 0xf1, 0xc8, 0xaf, 0x69, 0x71, 0x1c, 0xd9, 0x9f, 0xa1,
+	0x16, 0xa1, 0x44, 0x12, 0x9c, 0x04, 0x33, 0x20, 0xf1, 0x4c, 0x3a, 0xad, 0xbe, 0x75, 0xbc, 0xeb,
+	0x37, 0xf3, 0xe8, 0x73, 0x1d, 0xb4, 0xbf, 0x40, 0xb7, 0x13, 0x2c, 0xa4, 0x99, 0x58, 0xe1, 0xdc,
+	0xd5, 0xce, 0xb6, 0x12, 0x74, 0xe5, 0xb9, 0xd7, 0x47, 0xcd, 0x8a, 0x97, 0x44, 0xce, 0x8d, 0xed,
+	0xda, 0xcd, 0x66, 0xea, 0xac, 0xf1, 0xc8, 0xbb, 0xa3, 0x6a, 0x5f, 0x2d, 0x7b, 0x8d, 0x17, 0x05,
+	0x6a, 0x3c, 0xf2, 0x1b, 0x25, 0x77, 0x1c, 0xd9, 0x2f, 0x50, 0xbb, 0xc2, 0x54, 0x9d, 0xe4, 0xec,
+	0x69, 0x6a, 0xc7, 0x35, 0x6d, 0xe6, 0x16, 0x6d, 0xe6, 0x9e, 0x15, 0x6d, 0xe6, 0xd5, 0x15, 0xf6,
+	0xf5, 0xaf, 0x3d, 0xcb, 0x6f, 0x96, 0x2c, 0xa5, 0xda, 0xcf, 0x50, 0x9b, 0xc2, 0x42, 0x06, 0xe5,
+	0x79, 0x10, 0xce, 0x4d, 0x4d, 0xeb, 0x6e, 0xd7, 0x78, 0x5e, 0x78, 0x4e, 0x41, 0xfa, 0x2d, 0x95,
+	0x56, 0x46, 0x54, 0xc3, 0xa0, 0x0a, 0xa3, 0x76, 0x2d, 0x46, 0x25, 0x43, 0x15, 0xa2, 0xa7, 0x55,
+	0x81, 0xd4, 0xaf, 0x57, 0x88, 0x4a, 0xab, 0x14, 0x32, 0x44, 0x5d, 0x0d, 0x32, 0x3b, 0x53, 0xe1,
+	0x05, 0xe1, 0x0c, 0xd3, 0x18, 0x22, 0x67, 0x5f, 0x6f, 0xd6, 0x3d, 0xe5, 0x32, 0xfb, 0xb4, 0xce,
+	0x1e, 0x1a, 0x8b, 0xed, 0xa3, 0x83, 0x50, 0xf5, 0x25, 0x15, 0x17, 0x22, 0x30, 0x37, 0x81, 0x83,
+	0xb6, 0x4f, 0x81, 0x29, 0x67, 0x58, 0x38, 0x4f, 0xb4, 0x31, 0xef, 0xbf, 0x76, 0xb8, 0x19, 0xb6,
+	0xbf, 0x47, 0x9f, 0x56, 0x0b, 0xbb, 0xca, 0x2f, 0xcb, 0x6b, 0xe8, 0xf2, 0xfa, 0xeb, 0xf2, 0xae,
+	0xf0, 0x8b, 0x1a, 0x8b, 0x46, 0xe4, 0x20, 0x2e, 0x12, 0x29, 0x82, 0x19, 0x16, 0x33, 0xe7, 0x56,
+	0xdf, 0x3a, 0xbe, 0x65, 0x1a, 0xd1, 0x37, 0xf1, 0xe7, 0x58, 0xcc, 0xec, 0x43, 0x54, 0xc7, 0x59,
+	0x66, 0x2c, 0x4d, 0x6d, 0xa9, 0xe1, 0x2c, 0xd3, 0xd2, 0xe7, 0xf9, 0xc2, 0x67, 0x9c, 0xb1, 0xa9,
+	0x71, 0xfc, 0x5e, 0xd3, 0x16, 0xdd, 0x2a, 0x27, 0x2a, 0xac, 0x8d, 0x43, 0x64, 0xcf, 0xf9, 0x34,
+	0x48, 0x41, 0x08, 0x1c, 0x43, 0x10, 0xb1, 0x14, 0x13, 0xea, 0xfc, 0x51, 0xd3, 0x47, 0xea, 0xee,
+	0x6a, 0xd9, 0x3b, 0x38, 0xf7, 0x9f, 0xbe, 0x34, 0xea, 0x48, 0x8b, 0xfe, 0xc1, 0x9c, 0x4f, 0x37,
+	0x22, 0xf6, 0x37, 0xa8, 0xad, 0x20, 0x02, 0x20, 0x0a, 0x52, 0xb2, 0x20, 0x34, 0x76, 0xfe, 0x54,
+	0x84, 0xba, 0x77, 0x7b, 0xb5, 0xec, 0x35, 0xcf, 0xfd, 0xa7, 0xa7, 0x00, 0xd1, 0x4b, 0xad, 0xf8,
+	0xcd, 0x39, 0x9f, 0xae, 0x3f, 0xed, 0xc7, 0xe8, 0x7e, 0xc6, 0x59, 0xc6, 0x04, 0xf0, 0x40, 0x40,
+	0x02, 0xa1, 0x24, 0x8c, 0x06, 0x19, 0x87, 0x90, 0xe8, 0x8b, 0xe1, 0x2f, 0x05, 0x6a, 0xfa, 0x9d,
+	0xc2, 0x74, 0x5a, 0x78, 0x4e, 0x0a, 0x8b, 0xfd, 0x15, 0xfa, 0x30, 0xc5, 0x8b, 0x00, 0xe6, 0x24,
+	0x02, 0x1a, 0x42, 0x90, 0x01, 0xcf, 0x6f, 0xa8, 0xbf, 0x6b, 0x7a, 0xd9, 0xef, 0xa4, 0x78, 0xf1,
+	0x24, 0x57, 0x4f, 0x80, 0x9b, 0xcb, 0xec, 0x21, 0xb2, 0xcb, 0x1f, 0x4b, 0x02, 0xc1, 0x84, 0x03,
+	0x7e, 0xe5, 0xfc, 0xa3, 0x32, 0xf6, 0xfc, 0x83, 0x42, 0x3a, 0x23, 0xe0, 0x29, 0xc1, 0x7e, 0x80,
+	0x5a, 0xea, 0x27, 0x95, 0x4e, 0xfe, 0xd7, 0xc0, 0x9b, 0x29, 0x5e, 0x54, 0x3a, 0xf5, 0x5b, 0xd4,
+	0x09, 0x59, 0x9a, 0x12, 0x19, 0x94, 0xef, 0x41, 0x20, 0x59, 0x02, 0x1c, 0xd3, 0x10, 0x9c, 0xff,
+	0x4c, 0x8e, 0x63, 0x2c, 0xe5, 0x51, 0x3e, 0x2b, 0x0c, 0xde, 0xb3, 0x37, 0xab, 0xae, 0xf5, 0x76,
+	0xd5, 0xb5, 0x7e, 0x5b, 0x75, 0xad, 0xd7, 0x97, 0xdd, 0x9d, 0xb7, 0x97, 0xdd, 0x9d, 0x9f, 0x2f,
+	0xbb, 0x3b, 0x3f, 0x3c, 0x8c, 0x89, 0x9c, 0x5d, 0x4c, 0xdc, 0x90, 0xa5, 0x83, 0x84, 0x50, 0x18,
+	0x94, 0x4f, 0xa3, 0x79, 0x50, 0x37, 0x9f, 0xe1, 0xc9, 0x4d, 0x1d, 0xfd, 0xf2, 0xff, 0x01, 0x00,
+	0x89, 0x0d, 0x09, 0x87, 0x9f, 0x07, 0x00, 0x00,
 }
 
 func (m *ABCIResponses) Marshal() (dAtA []byte, err error) {
@@ -401,6 +472,62 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.CommitTimestampTolerance != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CommitTimestampTolerance))
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.MaxValidators != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxValidators))
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.ProposerTieBreak != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ProposerTieBreak))
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.MaxEvidencePerBlock != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxEvidencePerBlock))
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.ProposerSelectionPrecision != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ProposerSelectionPrecision))
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.VRFSeedMixing {
+		i--
+		if m.VRFSeedMixing {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xd0
+	}
+	if len(m.VRFMessageDomain) > 0 {
+		i -= len(m.VRFMessageDomain)
+		copy(dAtA[i:], m.VRFMessageDomain)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.VRFMessageDomain)))
+		i--
+		dAtA[i] = 0x3e
+		i--
+		dAtA[i] = 0xca
+	}
 	if len(m.LastProofHash) > 0 {
 		i -= len(m.LastProofHash)
 		copy(dAtA[i:], m.LastProofHash)
@@ -616,6 +743,28 @@ func (m *State) Size() (n int) {
 	if l > 0 {
 		n += 2 + l + sovTypes(uint64(l))
 	}
+	l = len(m.VRFMessageDomain)
+	if l > 0 {
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	if m.VRFSeedMixing {
+		n += 3
+	}
+	if m.ProposerSelectionPrecision != 0 {
+		n += 2 + sovTypes(uint64(m.ProposerSelectionPrecision))
+	}
+	if m.MaxEvidencePerBlock != 0 {
+		n += 2 + sovTypes(uint64(m.MaxEvidencePerBlock))
+	}
+	if m.ProposerTieBreak != 0 {
+		n += 2 + sovTypes(uint64(m.ProposerTieBreak))
+	}
+	if m.MaxValidators != 0 {
+		n += 2 + sovTypes(uint64(m.MaxValidators))
+	}
+	if m.CommitTimestampTolerance != 0 {
+		n += 2 + sovTypes(uint64(m.CommitTimestampTolerance))
+	}
 	return n
 }
 
@@ -1260,6 +1409,153 @@ func (m *State) Unmarshal(dAtA []byte) error {
 				m.LastProofHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 1001:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VRFMessageDomain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VRFMessageDomain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 1002:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VRFSeedMixing", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.VRFSeedMixing = bool(v != 0)
+		case 1003:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerSelectionPrecision", wireType)
+			}
+			m.ProposerSelectionPrecision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProposerSelectionPrecision |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 1004:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxEvidencePerBlock", wireType)
+			}
+			m.MaxEvidencePerBlock = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxEvidencePerBlock |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 1005:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerTieBreak", wireType)
+			}
+			m.ProposerTieBreak = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProposerTieBreak |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 1006:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxValidators", wireType)
+			}
+			m.MaxValidators = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxValidators |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 1007:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTimestampTolerance", wireType)
+			}
+			m.CommitTimestampTolerance = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitTimestampTolerance |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -9,6 +9,7 @@ import (
 	tmquery "github.com/line/ostracon/libs/pubsub/query"
 	ctypes "github.com/line/ostracon/rpc/core/types"
 	rpctypes "github.com/line/ostracon/rpc/jsonrpc/types"
+	sm "github.com/line/ostracon/state"
 	blockidxnull "github.com/line/ostracon/state/indexer/block/null"
 	"github.com/line/ostracon/types"
 )
@@ -113,9 +114,58 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 		return nil, err
 	}
 
+	return loadCommit(height), nil
+}
+
+// Commits gets the commits for from <= height <= to, in ascending order.
+// A zero from means the lowest height available, a zero to the latest one.
+// At most maxCommitsPerRequest commits are returned: if the range is larger,
+// the response holds the lowest ones and Truncated is set, so that the client
+// can continue from the height following the last commit.
+func Commits(ctx *rpctypes.Context, from, to int64) (*ctypes.ResultCommits, error) {
+	if from < 0 || to < 0 {
+		return nil, errors.New("heights must be non-negative")
+	}
+
+	base, height := env.BlockStore.Base(), env.BlockStore.Height()
+	if from == 0 || from < base {
+		from = base
+	}
+	if to == 0 || to > height {
+		to = height
+	}
+	if from > to {
+		return nil, fmt.Errorf("from height %d can't be greater than to height %d", from, to)
+	}
+
+	truncated := false
+	if to-from+1 > maxCommitsPerRequest {
+		to = from + maxCommitsPerRequest - 1
+		truncated = true
+	}
+
+	commits := make([]*ctypes.ResultCommit, 0, to-from+1)
+	for h := from; h <= to; h++ {
+		commit := loadCommit(h)
+		if commit == nil {
+			return nil, fmt.Errorf("commit at height %d not found", h)
+		}
+		commits = append(commits, commit)
+	}
+
+	return &ctypes.ResultCommits{
+		LastHeight: height,
+		Commits:    commits,
+		Truncated:  truncated,
+	}, nil
+}
+
+// loadCommit returns the commit for the block at the given height, or nil if
+// the block is not in the store.
+func loadCommit(height int64) *ctypes.ResultCommit {
 	blockMeta := env.BlockStore.LoadBlockMeta(height)
 	if blockMeta == nil {
-		return nil, nil
+		return nil
 	}
 	header := blockMeta.Header
 
@@ -123,12 +173,60 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 	// use a non-canonical commit
 	if height == env.BlockStore.Height() {
 		commit := env.BlockStore.LoadSeenCommit(height)
-		return ctypes.NewResultCommit(&header, commit, false), nil
+		return ctypes.NewResultCommit(&header, commit, false)
 	}
 
 	// Return the canonical commit (comes from the block at height+1)
 	commit := env.BlockStore.LoadBlockCommit(height)
-	return ctypes.NewResultCommit(&header, commit, true), nil
+	return ctypes.NewResultCommit(&header, commit, true)
+}
+
+// VRFProof gets the VRF proof recorded in the block at the given height along
+// with the proposer and the message it was computed over, so that a client can
+// run vrf.Verify locally.
+// If no height is provided, it will fetch the proof of the latest block.
+func VRFProof(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultVRFProof, error) {
+	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	if len(block.Proof) == 0 {
+		return nil, fmt.Errorf("no VRF proof recorded at height %d", height)
+	}
+
+	validators, err := env.StateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+	_, proposer := validators.GetByAddress(block.ProposerAddress)
+	if proposer == nil {
+		return nil, fmt.Errorf("proposer %X is not in the validator set at height %d", block.ProposerAddress, height)
+	}
+
+	proofHash, err := env.StateStore.LoadProofHash(height)
+	if err != nil {
+		return nil, err
+	}
+	// the ostracon consensus params are set at genesis, so the latest ones
+	// apply to any height
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultVRFProof{
+		BlockHeight:     height,
+		Round:           block.Round,
+		Proof:           block.Proof,
+		Message:         sm.VRFMessage(state.OCConsensusParams, proofHash, height-1, block.Round),
+		ProposerAddress: proposer.Address,
+		ProposerPubKey:  proposer.PubKey,
+	}, nil
 }
 
 // BlockResults gets ABCIResults at a given height.
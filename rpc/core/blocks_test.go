@@ -11,7 +11,9 @@ import (
 
 	cfg "github.com/line/ostracon/config"
 	"github.com/line/ostracon/crypto"
+	"github.com/line/ostracon/crypto/vrf"
 	tmrand "github.com/line/ostracon/libs/rand"
+	"github.com/line/ostracon/privval"
 	blockidxkv "github.com/line/ostracon/state/indexer/block/kv"
 	blockidxnull "github.com/line/ostracon/state/indexer/block/null"
 	"github.com/line/ostracon/store"
@@ -127,6 +129,95 @@ func TestBlockResults(t *testing.T) {
 	}
 }
 
+func TestCommits(t *testing.T) {
+	state, cleanup := makeTestState()
+	defer cleanup()
+	numBlocks := int64(maxCommitsPerRequest + 20)
+	storeTestBlocks(1, numBlocks, 0, state, time.Now())
+
+	// a range within the limit
+	res, err := Commits(&rpctypes.Context{}, 5, 14)
+	require.NoError(t, err)
+	assert.Equal(t, numBlocks, res.LastHeight)
+	assert.False(t, res.Truncated)
+	require.Len(t, res.Commits, 10)
+	for i, commit := range res.Commits {
+		assert.EqualValues(t, 5+i, commit.Height)
+		assert.True(t, commit.CanonicalCommit)
+	}
+
+	// the latest commit is not canonical yet
+	res, err = Commits(&rpctypes.Context{}, numBlocks, 0)
+	require.NoError(t, err)
+	require.Len(t, res.Commits, 1)
+	assert.Equal(t, numBlocks, res.Commits[0].Height)
+	assert.False(t, res.Commits[0].CanonicalCommit)
+
+	// an over-large range is truncated to the lowest heights
+	res, err = Commits(&rpctypes.Context{}, 0, 0)
+	require.NoError(t, err)
+	assert.True(t, res.Truncated)
+	require.Len(t, res.Commits, maxCommitsPerRequest)
+	assert.EqualValues(t, 1, res.Commits[0].Height)
+	assert.EqualValues(t, maxCommitsPerRequest, res.Commits[maxCommitsPerRequest-1].Height)
+
+	// invalid ranges
+	_, err = Commits(&rpctypes.Context{}, -1, 10)
+	assert.Error(t, err)
+	_, err = Commits(&rpctypes.Context{}, 10, 5)
+	assert.Error(t, err)
+}
+
+func TestVRFProof(t *testing.T) {
+	config := cfg.ResetTestRoot("rpc_core_test")
+	defer os.RemoveAll(config.RootDir)
+	env = &Environment{}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	env.BlockStore = store.NewBlockStore(dbm.NewMemDB())
+
+	state, err := env.StateStore.LoadFromDBOrGenesisFile(config.GenesisFile())
+	require.NoError(t, err)
+	require.NoError(t, env.StateStore.Save(state))
+
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	height := state.LastBlockHeight + 1
+	round := int32(0)
+	message := state.MakeHashMessage(round)
+	proof, err := privVal.GenerateVRFProof(message)
+	require.NoError(t, err)
+
+	commit := types.NewCommit(0, round, types.BlockID{}, nil)
+	block, _ := state.MakeBlock(height, nil, commit, nil, pubKey.Address(), round, proof)
+	env.BlockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), commit)
+
+	// a stored proof is returned and verifies against the returned message
+	res, err := VRFProof(&rpctypes.Context{}, &height)
+	require.NoError(t, err)
+	assert.Equal(t, height, res.BlockHeight)
+	assert.Equal(t, round, res.Round)
+	assert.EqualValues(t, proof, res.Proof)
+	assert.EqualValues(t, message, res.Message)
+	assert.Equal(t, pubKey.Address(), res.ProposerAddress)
+	valid, err := vrf.Verify(res.ProposerPubKey.Bytes(), vrf.Proof(res.Proof), res.Message)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// a height without a block has no proof
+	missing := height + 1
+	_, err = VRFProof(&rpctypes.Context{}, &missing)
+	assert.Error(t, err)
+
+	// neither has a block that didn't record one
+	env.BlockStore = store.NewBlockStore(dbm.NewMemDB())
+	block, _ = state.MakeBlock(height, nil, commit, nil, pubKey.Address(), round, nil)
+	env.BlockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), commit)
+	_, err = VRFProof(&rpctypes.Context{}, &height)
+	assert.Error(t, err)
+}
+
 func TestBlockSearchByBlockHeightQuery(t *testing.T) {
 	height := int64(1)
 	ctx := &rpctypes.Context{}
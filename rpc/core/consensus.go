@@ -1,6 +1,10 @@
 package core
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
 	cm "github.com/line/ostracon/consensus"
 	tmmath "github.com/line/ostracon/libs/math"
 	ctypes "github.com/line/ostracon/rpc/core/types"
@@ -27,6 +31,44 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *in
 		return nil, err
 	}
 
+	return validatorsPage(height, validators, pagePtr, perPagePtr)
+}
+
+// NextValidators gets the validator set active at the height following the
+// given block (maximum ?per_page entries), verified against the
+// NextValidatorsHash of its header. If no height is provided, it will fetch
+// the validator set following the latest block.
+func NextValidators(
+	ctx *rpctypes.Context,
+	heightPtr *int64,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultValidators, error) {
+	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	blockMeta := env.BlockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("block meta at height %d not found", height)
+	}
+	validators, err := env.StateStore.LoadValidators(height + 1)
+	if err != nil {
+		return nil, err
+	}
+	if hash := validators.Hash(); !bytes.Equal(hash, blockMeta.Header.NextValidatorsHash) {
+		return nil, fmt.Errorf("validator set hash %X doesn't match the next validators hash %X of height %d",
+			hash, blockMeta.Header.NextValidatorsHash, height)
+	}
+
+	return validatorsPage(height+1, validators, pagePtr, perPagePtr)
+}
+
+func validatorsPage(
+	height int64,
+	validators *types.ValidatorSet,
+	pagePtr, perPagePtr *int,
+) (*ctypes.ResultValidators, error) {
 	totalCount := len(validators.Validators)
 	perPage := validatePerPage(perPagePtr)
 	page, err := validatePage(pagePtr, perPage, totalCount)
@@ -45,6 +87,61 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64, pagePtr, perPagePtr *in
 		Total:       totalCount}, nil
 }
 
+// SigningStats counts the commits each validator signed and missed for the
+// blocks of from <= height <= to, e.g. to find the validators that are
+// consistently offline. As with Commits, a zero from means the lowest height
+// available, a zero to the latest one, and at most maxCommitsPerRequest
+// commits are counted.
+func SigningStats(ctx *rpctypes.Context, from, to int64) (*ctypes.ResultSigningStats, error) {
+	if from < 0 || to < 0 {
+		return nil, errors.New("heights must be non-negative")
+	}
+
+	base, height := env.BlockStore.Base(), env.BlockStore.Height()
+	if from == 0 || from < base {
+		from = base
+	}
+	if to == 0 || to > height {
+		to = height
+	}
+	if from > to {
+		return nil, fmt.Errorf("from height %d can't be greater than to height %d", from, to)
+	}
+
+	truncated := false
+	if to-from+1 > maxCommitsPerRequest {
+		to = from + maxCommitsPerRequest - 1
+		truncated = true
+	}
+
+	stats := types.NewSigningStats()
+	for h := from; h <= to; h++ {
+		var commit *types.Commit
+		if h == height {
+			commit = env.BlockStore.LoadSeenCommit(h)
+		} else {
+			commit = env.BlockStore.LoadBlockCommit(h)
+		}
+		if commit == nil {
+			return nil, fmt.Errorf("commit at height %d not found", h)
+		}
+		vals, err := env.StateStore.LoadValidators(h)
+		if err != nil {
+			return nil, err
+		}
+		if err := stats.AddCommit(vals, commit); err != nil {
+			return nil, fmt.Errorf("commit at height %d: %w", h, err)
+		}
+	}
+
+	return &ctypes.ResultSigningStats{
+		From:       from,
+		To:         to,
+		Validators: stats.Counts(),
+		Truncated:  truncated,
+	}, nil
+}
+
 // DumpConsensusState dumps consensus state.
 // UNSTABLE
 // More: https://docs.tendermint.com/master/rpc/#/Info/dump_consensus_state
@@ -106,3 +203,44 @@ func ConsensusParams(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCon
 		BlockHeight:     height,
 		ConsensusParams: consensusParams}, nil
 }
+
+// VerifyProposer replays the proposer selection for the block at the given
+// height and reports whether it matches the proposer recorded in the block.
+// If no height is provided, it will verify the latest block.
+func VerifyProposer(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultVerifyProposer, error) {
+	height, err := getHeight(env.BlockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	block := env.BlockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+
+	validators, err := env.StateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+
+	proofHash, err := env.StateStore.LoadProofHash(height)
+	if err != nil {
+		return nil, err
+	}
+
+	// the ostracon consensus params are set at genesis, so the latest ones
+	// apply to any height
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	proposer := validators.SelectProposerWithParams(state.OCConsensusParams, proofHash, height, block.Round)
+	return &ctypes.ResultVerifyProposer{
+		BlockHeight:      height,
+		Round:            block.Round,
+		ProposerAddress:  block.ProposerAddress,
+		SelectedProposer: proposer.Address,
+		Match:            bytes.Equal(block.ProposerAddress, proposer.Address),
+	}, nil
+}
@@ -1,19 +1,25 @@
 package core
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	cfg "github.com/line/ostracon/config"
 	"github.com/line/ostracon/consensus"
+	"github.com/line/ostracon/crypto"
+	tmrand "github.com/line/ostracon/libs/rand"
 	ctypes "github.com/line/ostracon/rpc/core/types"
 	rpctypes "github.com/line/ostracon/rpc/jsonrpc/types"
 	sm "github.com/line/ostracon/state"
 	"github.com/line/ostracon/state/mocks"
+	"github.com/line/ostracon/store"
 	"github.com/line/ostracon/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 )
 
@@ -104,3 +110,140 @@ func TestValidators(t *testing.T) {
 		})
 	}
 }
+
+func TestNextValidators(t *testing.T) {
+	current, _ := types.RandValidatorSet(2, 10)
+	next, _ := types.RandValidatorSet(3, 20)
+	blockStore := &mocks.BlockStore{}
+	stateStore := &mocks.Store{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(int64(2))
+	blockStore.On("LoadBlockMeta", int64(1)).Return(&types.BlockMeta{
+		// doesn't match the stored set of height 2
+		Header: types.Header{Height: 1, ValidatorsHash: current.Hash(), NextValidatorsHash: next.Hash()},
+	})
+	blockStore.On("LoadBlockMeta", int64(2)).Return(&types.BlockMeta{
+		Header: types.Header{Height: 2, ValidatorsHash: current.Hash(), NextValidatorsHash: next.Hash()},
+	})
+	stateStore.On("LoadValidators", int64(2)).Return(current, nil)
+	stateStore.On("LoadValidators", int64(3)).Return(next, nil)
+	env = &Environment{BlockStore: blockStore, StateStore: stateStore}
+
+	// the latest block by default
+	res, err := NextValidators(&rpctypes.Context{}, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &ctypes.ResultValidators{
+		BlockHeight: 3,
+		Validators:  next.Validators,
+		Count:       3,
+		Total:       3,
+	}, res)
+
+	page, perPage := 2, 2
+	h := int64(2)
+	res, err = NextValidators(&rpctypes.Context{}, &h, &page, &perPage)
+	require.NoError(t, err)
+	assert.Equal(t, next.Validators[2:], res.Validators)
+	assert.Equal(t, 3, res.Total)
+
+	// the hashes must match
+	h = 1
+	_, err = NextValidators(&rpctypes.Context{}, &h, nil, nil)
+	assert.Error(t, err)
+
+	h = 3
+	_, err = NextValidators(&rpctypes.Context{}, &h, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestVerifyProposer(t *testing.T) {
+	state, cleanup := makeTestState()
+	defer cleanup()
+	require.NoError(t, env.StateStore.Save(state))
+
+	height := int64(1)
+	round := int32(0)
+	proposer := state.Validators.SelectProposer(state.LastProofHash, height, round)
+	commit := types.NewCommit(0, round, types.BlockID{}, nil)
+
+	{
+		// the recorded proposer is the one selected by the VRF
+		block, _ := state.MakeBlock(height, nil, commit, nil, proposer.Address, round, nil)
+		env.BlockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), commit)
+
+		res, err := VerifyProposer(&rpctypes.Context{}, &height)
+		require.NoError(t, err)
+		assert.True(t, res.Match)
+		assert.Equal(t, proposer.Address, res.ProposerAddress)
+		assert.Equal(t, proposer.Address, res.SelectedProposer)
+	}
+	{
+		// the recorded proposer has been tampered with
+		env.BlockStore = store.NewBlockStore(dbm.NewMemDB())
+		tampered := types.Address(tmrand.Bytes(crypto.AddressSize))
+		block, _ := state.MakeBlock(height, nil, commit, nil, tampered, round, nil)
+		env.BlockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), commit)
+
+		res, err := VerifyProposer(&rpctypes.Context{}, &height)
+		require.NoError(t, err)
+		assert.False(t, res.Match)
+		assert.Equal(t, tampered, res.ProposerAddress)
+		assert.Equal(t, proposer.Address, res.SelectedProposer)
+	}
+	{
+		// unknown height
+		invalid := height + 1
+		_, err := VerifyProposer(&rpctypes.Context{}, &invalid)
+		require.Error(t, err)
+	}
+}
+
+func TestSigningStats(t *testing.T) {
+	const offline = 1
+	vals, privVals := types.RandValidatorSet(4, 10)
+	blockStore := &mocks.BlockStore{}
+	stateStore := &mocks.Store{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(int64(3))
+	for h := int64(1); h <= 3; h++ {
+		voteSet := types.NewVoteSet("test_chain_id", h, 0, tmproto.PrecommitType, vals)
+		blockID := types.BlockID{Hash: tmrand.Bytes(32), PartSetHeader: types.PartSetHeader{Total: 1, Hash: tmrand.Bytes(32)}}
+		commit, err := types.MakeCommit(blockID, h, 0, voteSet, privVals, time.Now())
+		require.NoError(t, err)
+		commit.Signatures[offline] = types.NewCommitSigAbsent()
+		if h == 3 {
+			blockStore.On("LoadSeenCommit", h).Return(commit)
+		} else {
+			blockStore.On("LoadBlockCommit", h).Return(commit)
+		}
+		stateStore.On("LoadValidators", h).Return(vals, nil)
+	}
+	env = &Environment{BlockStore: blockStore, StateStore: stateStore}
+
+	res, err := SigningStats(&rpctypes.Context{}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.From)
+	assert.Equal(t, int64(3), res.To)
+	assert.False(t, res.Truncated)
+	require.Len(t, res.Validators, 4)
+	for _, count := range res.Validators {
+		if bytes.Equal(count.Address, vals.Validators[offline].Address) {
+			assert.Equal(t, int64(3), count.Missed)
+			assert.Equal(t, 1.0, count.MissRate())
+		} else {
+			assert.Equal(t, int64(3), count.Signed)
+			assert.Equal(t, 0.0, count.MissRate())
+		}
+	}
+
+	res, err = SigningStats(&rpctypes.Context{}, 2, 2)
+	require.NoError(t, err)
+	for _, count := range res.Validators {
+		assert.Equal(t, int64(1), count.Signed+count.Missed)
+	}
+
+	_, err = SigningStats(&rpctypes.Context{}, 3, 2)
+	assert.Error(t, err)
+	_, err = SigningStats(&rpctypes.Context{}, -1, 0)
+	assert.Error(t, err)
+}
@@ -27,12 +27,17 @@ const (
 	// TODO It will be modified later to be configurable. (Also, add a option to get all tx of block)
 	maxPerPage = 10000
 
+	// maxCommitsPerRequest is the maximum number of commits returned by a
+	// single /commits request.
+	maxCommitsPerRequest = 100
+
 	// SubscribeTimeout is the maximum time we wait to subscribe for an event.
 	// must be less than the server's write timeout (see rpcserver.DefaultConfig)
 	SubscribeTimeout = 5 * time.Second
 
-	// genesisChunkSize is the maximum size, in bytes, of each
-	// chunk in the genesis structure for the chunked API
+	// genesisChunkSize is the default maximum size, in bytes, of each
+	// chunk in the genesis structure for the chunked API, used if
+	// RPCConfig.GenesisChunkSize is not set
 	genesisChunkSize = 16 * 1024 * 1024 // 16
 )
 
@@ -158,8 +163,13 @@ func InitGenesisChunks() error {
 		return err
 	}
 
-	for i := 0; i < len(data); i += genesisChunkSize {
-		end := i + genesisChunkSize
+	chunkSize := env.Config.GenesisChunkSize
+	if chunkSize <= 0 {
+		chunkSize = genesisChunkSize
+	}
+
+	for i := 0; i < len(data); i += chunkSize {
+		end := i + chunkSize
 
 		if end > len(data) {
 			end = len(data)
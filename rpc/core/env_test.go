@@ -1,9 +1,13 @@
 package core
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 
+	tmjson "github.com/line/ostracon/libs/json"
+	rpctypes "github.com/line/ostracon/rpc/jsonrpc/types"
 	"github.com/line/ostracon/types"
 	"github.com/stretchr/testify/require"
 
@@ -103,3 +107,37 @@ func TestInitGenesisChunks(t *testing.T) {
 	err = InitGenesisChunks()
 	require.NoError(t, err)
 }
+
+func TestInitGenesisChunksSize(t *testing.T) {
+	genDoc := &types.GenesisDoc{
+		ChainID:  "test-chain",
+		AppState: []byte(`"` + strings.Repeat("a", 5000) + `"`),
+	}
+	data, err := tmjson.Marshal(genDoc)
+	require.NoError(t, err)
+
+	for _, chunkSize := range []int{0, 100, 1000, len(data), len(data) + 1} {
+		env = &Environment{GenDoc: genDoc}
+		env.Config.GenesisChunkSize = chunkSize
+		require.NoError(t, InitGenesisChunks())
+
+		expected := 1
+		if chunkSize > 0 {
+			expected = (len(data) + chunkSize - 1) / chunkSize
+		}
+		res, err := GenesisChunked(&rpctypes.Context{}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, expected, res.TotalChunks, "chunk size %d", chunkSize)
+
+		// the chunks add up to the genesis doc
+		var joined []byte
+		for i := 0; i < res.TotalChunks; i++ {
+			res, err := GenesisChunked(&rpctypes.Context{}, uint(i))
+			require.NoError(t, err)
+			chunk, err := base64.StdEncoding.DecodeString(res.Data)
+			require.NoError(t, err)
+			joined = append(joined, chunk...)
+		}
+		assert.Equal(t, data, joined)
+	}
+}
@@ -42,7 +42,39 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 	if err != nil {
 		return nil, err
 	}
-	r := res.GetCheckTx()
+	return broadcastTxResult(tx, res.GetCheckTx()), nil
+}
+
+// BroadcastTxs broadcasts the transactions in order, like BroadcastTxSync, and
+// returns the result of each at its index. The transactions are independent: a
+// transaction rejected by the mempool before CheckTx, e.g. by the pre-check,
+// is marked Rejected with the reason in MempoolError, and the next ones are
+// still broadcast. The number of transactions is limited by
+// max_broadcast_txs_batch_size.
+func BroadcastTxs(ctx *rpctypes.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxs, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("no txs to broadcast")
+	}
+	if maxTxs := env.Config.MaxBroadcastTxsBatchSize; maxTxs > 0 && len(txs) > maxTxs {
+		return nil, fmt.Errorf("too many txs to broadcast: %d (max: %d)", len(txs), maxTxs)
+	}
+	results := make([]ctypes.ResultBroadcastTx, len(txs))
+	for i, tx := range txs {
+		res, err := env.Mempool.CheckTxSync(tx, mempl.TxInfo{})
+		if err != nil {
+			results[i] = ctypes.ResultBroadcastTx{
+				MempoolError: err.Error(),
+				Rejected:     true,
+				Hash:         tx.Hash(),
+			}
+			continue
+		}
+		results[i] = *broadcastTxResult(tx, res.GetCheckTx())
+	}
+	return &ctypes.ResultBroadcastTxs{Txs: results}, nil
+}
+
+func broadcastTxResult(tx types.Tx, r *ocabci.ResponseCheckTx) *ctypes.ResultBroadcastTx {
 	return &ctypes.ResultBroadcastTx{
 		Code:         r.Code,
 		Data:         r.Data,
@@ -50,7 +82,7 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 		Codespace:    r.Codespace,
 		MempoolError: r.MempoolError,
 		Hash:         tx.Hash(),
-	}, nil
+	}
 }
 
 // BroadcastTxCommit returns with the responses from CheckTx and DeliverTx.
@@ -154,6 +186,16 @@ func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, err
 		TotalBytes: env.Mempool.TxsBytes()}, nil
 }
 
+// MempoolTx gets the unconfirmed transaction with the given hash. It returns
+// an error if the transaction is not in the mempool, e.g. already committed.
+func MempoolTx(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultMempoolTx, error) {
+	tx, ok := env.Mempool.GetByHash(hash)
+	if !ok {
+		return nil, fmt.Errorf("tx (%X) not found in the mempool", hash)
+	}
+	return &ctypes.ResultMempoolTx{Hash: hash, Tx: tx}, nil
+}
+
 // CheckTx checks the transaction without executing it. The transaction won't
 // be added to the mempool either.
 // More: https://docs.tendermint.com/master/rpc/#/Tx/check_tx
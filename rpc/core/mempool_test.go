@@ -0,0 +1,96 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocabci "github.com/line/ostracon/abci/types"
+	mempl "github.com/line/ostracon/mempool"
+	"github.com/line/ostracon/mempool/mock"
+	rpctypes "github.com/line/ostracon/rpc/jsonrpc/types"
+	"github.com/line/ostracon/types"
+)
+
+// pendingMempool is a mempool holding the given txs.
+type pendingMempool struct {
+	mock.Mempool
+	txs types.Txs
+}
+
+func (mem pendingMempool) GetByHash(hash []byte) (types.Tx, bool) {
+	if i := mem.txs.IndexByHash(hash); i >= 0 {
+		return mem.txs[i], true
+	}
+	return nil, false
+}
+
+func TestMempoolTx(t *testing.T) {
+	pending := types.Tx("pending")
+	env = &Environment{Mempool: pendingMempool{txs: types.Txs{pending}}}
+
+	res, err := MempoolTx(&rpctypes.Context{}, pending.Hash())
+	require.NoError(t, err)
+	assert.EqualValues(t, pending.Hash(), res.Hash)
+	assert.Equal(t, pending, res.Tx)
+
+	_, err = MempoolTx(&rpctypes.Context{}, types.Tx("committed").Hash())
+	assert.Error(t, err)
+}
+
+// admissionMempool pre-checks the txs like CListMempool and rejects the txs
+// "invalid" in CheckTx.
+type admissionMempool struct {
+	mock.Mempool
+	preCheck mempl.PreCheckFunc
+}
+
+func (mem admissionMempool) CheckTxSync(tx types.Tx, _ mempl.TxInfo) (*ocabci.Response, error) {
+	if err := mem.preCheck(tx); err != nil {
+		return nil, mempl.ErrPreCheck{Reason: err}
+	}
+	if string(tx) == "invalid" {
+		return ocabci.ToResponseCheckTx(ocabci.ResponseCheckTx{Code: 5, Log: "invalid tx"}), nil
+	}
+	return ocabci.ToResponseCheckTx(ocabci.ResponseCheckTx{Code: ocabci.CodeTypeOK}), nil
+}
+
+func TestBroadcastTxs(t *testing.T) {
+	env = &Environment{Mempool: admissionMempool{preCheck: mempl.PreCheckMaxBytes(100)}}
+
+	txs := []types.Tx{
+		types.Tx("ok1"),
+		types.Tx(make([]byte, 200)), // too big
+		types.Tx("invalid"),
+		types.Tx("ok2"),
+	}
+	res, err := BroadcastTxs(&rpctypes.Context{}, txs)
+	require.NoError(t, err)
+	require.Len(t, res.Txs, len(txs))
+	for i, tx := range txs {
+		assert.EqualValues(t, tx.Hash(), res.Txs[i].Hash, "tx #%d", i)
+	}
+
+	assert.Equal(t, ocabci.CodeTypeOK, res.Txs[0].Code)
+	assert.Empty(t, res.Txs[0].MempoolError)
+	assert.False(t, res.Txs[0].Rejected)
+
+	assert.True(t, res.Txs[1].Rejected)
+	assert.Contains(t, res.Txs[1].MempoolError, "too big")
+
+	assert.EqualValues(t, 5, res.Txs[2].Code)
+	assert.Equal(t, "invalid tx", res.Txs[2].Log)
+	assert.Empty(t, res.Txs[2].MempoolError)
+	assert.False(t, res.Txs[2].Rejected)
+
+	assert.Equal(t, ocabci.CodeTypeOK, res.Txs[3].Code)
+
+	_, err = BroadcastTxs(&rpctypes.Context{}, nil)
+	assert.Error(t, err)
+
+	// the batch size is limited
+	env.Config.MaxBroadcastTxsBatchSize = len(txs) - 1
+	_, err = BroadcastTxs(&rpctypes.Context{}, txs)
+	assert.Error(t, err)
+}
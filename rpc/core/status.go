@@ -1,8 +1,11 @@
 package core
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/line/ostracon/crypto/ed25519"
+	"github.com/line/ostracon/crypto/vrf"
 	tmbytes "github.com/line/ostracon/libs/bytes"
 	"github.com/line/ostracon/p2p"
 	ctypes "github.com/line/ostracon/rpc/core/types"
@@ -83,3 +86,17 @@ func validatorAtHeight(h int64) *types.Validator {
 	_, val := vals.GetByAddress(privValAddress)
 	return val
 }
+
+// VRFPubKey returns the public key the node proves and verifies VRF proofs
+// with, so that the proofs of its proposals can be verified externally, along
+// with the library implementing the VRF.
+func VRFPubKey(ctx *rpctypes.Context) (*ctypes.ResultVRFPubKey, error) {
+	if err := vrf.Available(); err != nil {
+		return nil, err
+	}
+	// the VRF is computed with the consensus key, which only ed25519 supports
+	if _, ok := env.PubKey.(ed25519.PubKey); !ok {
+		return nil, fmt.Errorf("VRF is not supported by %s pubkeys", env.PubKey.Type())
+	}
+	return &ctypes.ResultVRFPubKey{PubKey: env.PubKey, Backend: vrf.Backend()}, nil
+}
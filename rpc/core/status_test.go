@@ -0,0 +1,35 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/line/ostracon/crypto/ed25519"
+	"github.com/line/ostracon/crypto/secp256k1"
+	"github.com/line/ostracon/crypto/vrf"
+	rpctypes "github.com/line/ostracon/rpc/jsonrpc/types"
+)
+
+func TestVRFPubKey(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	env = &Environment{PubKey: privKey.PubKey()}
+
+	res, err := VRFPubKey(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, privKey.PubKey(), res.PubKey)
+	assert.Equal(t, vrf.Backend(), res.Backend)
+	assert.NotEmpty(t, res.Backend)
+
+	// the proofs of the node verify with the returned key
+	message := []byte("message")
+	proof, err := privKey.VRFProve(message)
+	require.NoError(t, err)
+	_, err = res.PubKey.VRFVerify(proof, message)
+	assert.NoError(t, err)
+
+	env = &Environment{PubKey: secp256k1.GenPrivKey().PubKey()}
+	_, err = VRFPubKey(&rpctypes.Context{})
+	assert.Error(t, err)
+}
@@ -41,12 +41,38 @@ type ResultBlock struct {
 	Block   *types.Block  `json:"block"`
 }
 
+// VRF proof of the block proposer at a given height. The proof can be checked
+// with vrf.Verify(ProposerPubKey, Proof, Message).
+type ResultVRFProof struct {
+	BlockHeight     int64          `json:"block_height"`
+	Round           int32          `json:"round"`
+	Proof           bytes.HexBytes `json:"proof"`
+	Message         bytes.HexBytes `json:"message"`
+	ProposerAddress types.Address  `json:"proposer_address"`
+	ProposerPubKey  crypto.PubKey  `json:"proposer_pub_key"`
+}
+
+// VRF public key of the node, with the library implementing the VRF.
+type ResultVRFPubKey struct {
+	PubKey  crypto.PubKey `json:"pub_key"`
+	Backend string        `json:"backend"`
+}
+
 // Commit and Header
 type ResultCommit struct {
 	types.SignedHeader `json:"signed_header"`
 	CanonicalCommit    bool `json:"canonical"`
 }
 
+// Commits for a range of heights
+type ResultCommits struct {
+	LastHeight int64           `json:"last_height"`
+	Commits    []*ResultCommit `json:"commits"`
+	// true if the range was larger than the maximum number of commits per
+	// request and the highest commits were left out
+	Truncated bool `json:"truncated"`
+}
+
 // ABCI results from a block
 type ResultBlockResults struct {
 	Height                int64                     `json:"height"`
@@ -150,6 +176,25 @@ type ResultConsensusParams struct {
 	ConsensusParams tmproto.ConsensusParams `json:"consensus_params"`
 }
 
+// Result of replaying the proposer selection for a given height
+type ResultVerifyProposer struct {
+	BlockHeight      int64         `json:"block_height"`
+	Round            int32         `json:"round"`
+	ProposerAddress  types.Address `json:"proposer_address"`
+	SelectedProposer types.Address `json:"selected_proposer"`
+	Match            bool          `json:"match"`
+}
+
+// Number of commits each validator signed and missed in a range of heights
+type ResultSigningStats struct {
+	From       int64                `json:"from"`
+	To         int64                `json:"to"`
+	Validators []types.SigningCount `json:"validators"`
+	// true if the range was larger than the maximum number of commits per
+	// request and the highest commits were left out
+	Truncated bool `json:"truncated"`
+}
+
 // Info about the consensus state.
 // UNSTABLE
 type ResultDumpConsensusState struct {
@@ -175,10 +220,19 @@ type ResultBroadcastTx struct {
 	Log          string         `json:"log"`
 	Codespace    string         `json:"codespace"`
 	MempoolError string         `json:"mempool_error"`
+	// Rejected is set by /broadcast_txs for a tx rejected by the mempool before
+	// CheckTx, e.g. by the pre-check. The reason is in MempoolError and the
+	// CheckTx fields are empty.
+	Rejected bool `json:"rejected,omitempty"`
 
 	Hash bytes.HexBytes `json:"hash"`
 }
 
+// CheckTx results of the txs of /broadcast_txs, in order
+type ResultBroadcastTxs struct {
+	Txs []ResultBroadcastTx `json:"txs"`
+}
+
 // CheckTx and DeliverTx results
 type ResultBroadcastTxCommit struct {
 	CheckTx   ocabci.ResponseCheckTx `json:"check_tx"`
@@ -222,6 +276,12 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// Result of querying for an unconfirmed tx
+type ResultMempoolTx struct {
+	Hash bytes.HexBytes `json:"hash"`
+	Tx   types.Tx       `json:"tx"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -3,11 +3,14 @@ package server
 
 import (
 	"bufio"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
+	"expvar"
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"runtime/debug"
 	"strings"
@@ -34,6 +37,17 @@ type Config struct {
 	MaxBodyBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
+	// MaxSubscriptionsPerConnection limits the number of concurrent
+	// subscriptions of a websocket connection (see SubscriptionLimits).
+	// 0 means unlimited.
+	MaxSubscriptionsPerConnection int
+	// MaxSubscriptions limits the number of concurrent subscriptions of all
+	// the websocket connections (see SubscriptionLimits). 0 means unlimited.
+	MaxSubscriptions int
+	// ListenBacklog is the maximum length of the queue of pending connections
+	// of the listener (see Listen). 0 means the operating system's default
+	// (somaxconn on Linux).
+	ListenBacklog int
 }
 
 // DefaultConfig returns a default configuration.
@@ -45,6 +59,11 @@ func DefaultConfig() *Config {
 		IdleTimeout:        60 * time.Second,
 		MaxBodyBytes:       int64(1000000), // 1MB
 		MaxHeaderBytes:     1 << 20,        // same as the net/http default
+
+		MaxSubscriptionsPerConnection: 0, // unlimited
+		MaxSubscriptions:              0, // unlimited
+
+		ListenBacklog: 0, // the operating system's default
 	}
 }
 
@@ -256,9 +275,49 @@ func Listen(addr string, config *Config) (listener net.Listener, err error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on %v: %v", addr, err)
 	}
+	if config.ListenBacklog > 0 {
+		if err := setListenBacklog(listener, config.ListenBacklog); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to set the listen backlog of %v: %w", addr, err)
+		}
+	}
 	if config.MaxOpenConnections > 0 {
 		listener = netutil.LimitListener(listener, config.MaxOpenConnections)
 	}
 
 	return listener, nil
 }
+
+// RegisterDebugHandlers mounts the pprof handlers on /debug/pprof/ and the
+// expvar handler on /debug/vars. If authToken is not empty, the requests must
+// have the "Authorization: Bearer <authToken>" header.
+func RegisterDebugHandlers(mux *http.ServeMux, authToken string) {
+	handle := func(pattern string, handler http.Handler) {
+		if authToken != "" {
+			handler = bearerAuthHandler{h: handler, token: authToken}
+		}
+		mux.Handle(pattern, handler)
+	}
+	handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
+	handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
+	handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
+	handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
+	handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
+	handle("/debug/vars", expvar.Handler())
+}
+
+// bearerAuthHandler rejects the requests without the bearer token.
+type bearerAuthHandler struct {
+	h     http.Handler
+	token string
+}
+
+func (h bearerAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	got := []byte(r.Header.Get("Authorization"))
+	want := []byte("Bearer " + h.token)
+	if subtle.ConstantTimeCompare(got, want) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.h.ServeHTTP(w, r)
+}
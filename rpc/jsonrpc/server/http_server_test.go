@@ -278,3 +278,34 @@ func TestRecoverAndLogHandler_RPCResponseNG_WriteRPCResponseHTTPError_error_pani
 		strconv.Itoa(http.StatusInternalServerError),
 		rec.Header().Get(http.StatusText(http.StatusInternalServerError)))
 }
+
+func TestRegisterDebugHandlers(t *testing.T) {
+	get := func(mux *http.ServeMux, path, auth string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// not registered
+	mux := http.NewServeMux()
+	assert.Equal(t, http.StatusNotFound, get(mux, "/debug/pprof/", ""))
+	assert.Equal(t, http.StatusNotFound, get(mux, "/debug/vars", ""))
+
+	// registered without auth
+	mux = http.NewServeMux()
+	RegisterDebugHandlers(mux, "")
+	assert.Equal(t, http.StatusOK, get(mux, "/debug/pprof/", ""))
+	assert.Equal(t, http.StatusOK, get(mux, "/debug/vars", ""))
+
+	// registered with auth
+	mux = http.NewServeMux()
+	RegisterDebugHandlers(mux, "secret")
+	assert.Equal(t, http.StatusUnauthorized, get(mux, "/debug/pprof/", ""))
+	assert.Equal(t, http.StatusUnauthorized, get(mux, "/debug/pprof/", "Bearer wrong"))
+	assert.Equal(t, http.StatusOK, get(mux, "/debug/pprof/", "Bearer secret"))
+	assert.Equal(t, http.StatusOK, get(mux, "/debug/vars", "Bearer secret"))
+}
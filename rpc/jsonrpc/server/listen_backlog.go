@@ -0,0 +1,35 @@
+//go:build !windows
+// +build !windows
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// sysListen is syscall.Listen, a variable for the tests.
+var sysListen = syscall.Listen
+
+// setListenBacklog sets the backlog of a listening socket by calling listen(2)
+// again, which only updates the backlog of a socket already listening.
+func setListenBacklog(listener net.Listener, backlog int) error {
+	sc, ok := listener.(interface {
+		SyscallConn() (syscall.RawConn, error)
+	})
+	if !ok {
+		return fmt.Errorf("listener %T has no socket", listener)
+	}
+	rc, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var listenErr error
+	if err := rc.Control(func(fd uintptr) {
+		listenErr = sysListen(int(fd), backlog)
+	}); err != nil {
+		return err
+	}
+	return listenErr
+}
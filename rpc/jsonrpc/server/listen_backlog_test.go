@@ -0,0 +1,37 @@
+//go:build !windows
+// +build !windows
+
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenBacklog(t *testing.T) {
+	var backlogs []int
+	defaultSysListen := sysListen
+	sysListen = func(fd int, backlog int) error {
+		backlogs = append(backlogs, backlog)
+		return defaultSysListen(fd, backlog)
+	}
+	t.Cleanup(func() { sysListen = defaultSysListen })
+
+	config := DefaultConfig()
+	config.ListenBacklog = 4096
+	for _, addr := range []string{"tcp://127.0.0.1:0", "unix://" + t.TempDir() + "/rpc.sock"} {
+		l, err := Listen(addr, config)
+		require.NoError(t, err)
+		require.NoError(t, l.Close())
+	}
+	assert.Equal(t, []int{4096, 4096}, backlogs)
+
+	// the default keeps the backlog of the operating system
+	backlogs = nil
+	l, err := Listen("tcp://127.0.0.1:0", DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+	assert.Empty(t, backlogs)
+}
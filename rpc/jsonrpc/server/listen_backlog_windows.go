@@ -0,0 +1,13 @@
+//go:build windows
+// +build windows
+
+package server
+
+import (
+	"errors"
+	"net"
+)
+
+func setListenBacklog(listener net.Listener, backlog int) error {
+	return errors.New("setting the listen backlog is not supported on windows")
+}
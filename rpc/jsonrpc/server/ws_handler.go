@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"reflect"
 	"runtime/debug"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -136,6 +137,11 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// limits the number of subscriptions, nil if unlimited
+	subLimiter *subscriptionLimiter
+	// number of subscriptions of the connection, guarded by subLimiter
+	numSubscriptions int
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -177,6 +183,20 @@ func OnDisconnect(onDisconnect func(remoteAddr string)) func(*wsConnection) {
 	}
 }
 
+// SubscriptionLimits limits the number of concurrent subscriptions of each
+// connection (perConnection) and of all the connections sharing the option
+// (total), i.e. of all the connections of a WebsocketManager. A subscription
+// past a limit is rejected. Zero means unlimited.
+func SubscriptionLimits(perConnection, total int) func(*wsConnection) {
+	if perConnection <= 0 && total <= 0 {
+		return func(*wsConnection) {}
+	}
+	limiter := &subscriptionLimiter{maxPerConnection: perConnection, max: total}
+	return func(wsc *wsConnection) {
+		wsc.subLimiter = limiter
+	}
+}
+
 // WriteWait sets the amount of time to wait before a websocket write times out.
 // It should only be used in the constructor - not Goroutine-safe.
 func WriteWait(writeWait time.Duration) func(*wsConnection) {
@@ -236,6 +256,9 @@ func (wsc *wsConnection) OnStop() {
 	if wsc.onDisconnect != nil {
 		wsc.onDisconnect(wsc.remoteAddr)
 	}
+	if wsc.subLimiter != nil {
+		wsc.subLimiter.releaseAll(wsc)
+	}
 
 	if wsc.ctx != nil {
 		wsc.cancel()
@@ -378,12 +401,24 @@ func (wsc *wsConnection) readRoutine() {
 				args = append(args, fnArgs...)
 			}
 
+			if request.Method == "subscribe" && wsc.subLimiter != nil {
+				if err := wsc.subLimiter.acquire(wsc); err != nil {
+					if err := wsc.WriteRPCResponse(writeCtx, types.RPCInvalidRequestError(request.ID, err)); err != nil {
+						wsc.Logger.Error("Error writing RPC response", "err", err)
+					}
+					continue
+				}
+			}
+
 			returns := rpcFunc.f.Call(args)
 
 			// TODO: Need to encode args/returns to string if we want to log them
 			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
 
 			result, err := unreflectResult(returns)
+			if wsc.subLimiter != nil {
+				wsc.subLimiter.update(wsc, request.Method, err == nil)
+			}
 			if err != nil {
 				if err := wsc.WriteRPCResponse(writeCtx, types.RPCInternalError(request.ID, err)); err != nil {
 					wsc.Logger.Error("Error writing RPC response", "err", err)
@@ -453,3 +488,60 @@ func (wsc *wsConnection) writeMessageWithDeadline(msgType int, msg []byte) error
 	}
 	return wsc.baseConn.WriteMessage(msgType, msg)
 }
+
+// subscriptionLimiter counts the subscriptions of the connections sharing it,
+// see SubscriptionLimits.
+type subscriptionLimiter struct {
+	mtx              sync.Mutex
+	maxPerConnection int
+	max              int
+	total            int
+}
+
+// acquire reserves a subscription for wsc, before calling subscribe. It
+// returns an error if a limit is reached.
+func (l *subscriptionLimiter) acquire(wsc *wsConnection) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.maxPerConnection > 0 && wsc.numSubscriptions >= l.maxPerConnection {
+		return fmt.Errorf("max subscriptions per connection (%d) reached", l.maxPerConnection)
+	}
+	if l.max > 0 && l.total >= l.max {
+		return fmt.Errorf("max subscriptions (%d) reached", l.max)
+	}
+	wsc.numSubscriptions++
+	l.total++
+	return nil
+}
+
+// update updates the subscriptions of wsc after a call to method: a failed
+// subscribe gives back the subscription reserved by acquire and a successful
+// unsubscribe (unsubscribe_all) frees one (all) of the subscriptions.
+func (l *subscriptionLimiter) update(wsc *wsConnection, method string, succeeded bool) {
+	switch {
+	case method == "subscribe" && !succeeded, method == "unsubscribe" && succeeded:
+		l.release(wsc, 1)
+	case method == "unsubscribe_all" && succeeded:
+		l.releaseAll(wsc)
+	}
+}
+
+func (l *subscriptionLimiter) release(wsc *wsConnection, n int) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if n > wsc.numSubscriptions {
+		n = wsc.numSubscriptions
+	}
+	wsc.numSubscriptions -= n
+	l.total -= n
+}
+
+func (l *subscriptionLimiter) releaseAll(wsc *wsConnection) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.total -= wsc.numSubscriptions
+	wsc.numSubscriptions = 0
+}
@@ -1,9 +1,11 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
@@ -54,3 +56,74 @@ func newWSServer() *httptest.Server {
 
 	return httptest.NewServer(mux)
 }
+
+func TestWebsocketManagerSubscriptionLimits(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"subscribe": NewWSRPCFunc(func(ctx *types.Context, query string) (string, error) {
+			if query == "invalid" {
+				return "", errors.New("invalid query")
+			}
+			return "subscribed", nil
+		}, "query"),
+		"unsubscribe":     NewWSRPCFunc(func(ctx *types.Context, query string) (string, error) { return "", nil }, "query"),
+		"unsubscribe_all": NewWSRPCFunc(func(ctx *types.Context) (string, error) { return "", nil }, ""),
+	}
+	wm := NewWebsocketManager(funcMap, SubscriptionLimits(2, 3))
+	wm.SetLogger(log.TestingLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	dial := func() *websocket.Conn {
+		c, dialResp, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+		require.NoError(t, err)
+		dialResp.Body.Close()
+		t.Cleanup(func() { c.Close() })
+		return c
+	}
+	call := func(c *websocket.Conn, method, query string) *types.RPCError {
+		params := map[string]interface{}{}
+		if method != "unsubscribe_all" {
+			params["query"] = query
+		}
+		req, err := types.MapToRequest(types.JSONRPCStringID("ws"), method, params)
+		require.NoError(t, err)
+		require.NoError(t, c.WriteJSON(req))
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		return resp.Error
+	}
+
+	c1, c2 := dial(), dial()
+
+	// per connection limit
+	require.Nil(t, call(c1, "subscribe", "a"))
+	require.Nil(t, call(c1, "subscribe", "b"))
+	rpcErr := call(c1, "subscribe", "c")
+	require.NotNil(t, rpcErr)
+	require.Contains(t, rpcErr.Data, "max subscriptions per connection (2) reached")
+
+	// cancelling one frees a slot
+	require.Nil(t, call(c1, "unsubscribe", "a"))
+	require.Nil(t, call(c1, "subscribe", "c"))
+
+	// failed subscriptions don't take a slot
+	require.NotNil(t, call(c2, "subscribe", "invalid"))
+
+	// global limit
+	require.Nil(t, call(c2, "subscribe", "a"))
+	rpcErr = call(c2, "subscribe", "b")
+	require.NotNil(t, rpcErr)
+	require.Contains(t, rpcErr.Data, "max subscriptions (3) reached")
+
+	// unsubscribing all frees the slots of the connection
+	require.Nil(t, call(c1, "unsubscribe_all", ""))
+	require.Nil(t, call(c2, "subscribe", "b"))
+
+	// so does closing a connection
+	require.Nil(t, call(c1, "subscribe", "a"))
+	require.NotNil(t, call(c1, "subscribe", "b"))
+	require.NoError(t, c2.Close())
+	require.Eventually(t, func() bool { return call(c1, "subscribe", "b") == nil }, time.Second, 10*time.Millisecond)
+}
@@ -45,6 +45,9 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// optional log of validator set changes
+	valSetChangeLog ValidatorSetChangeLog
 }
 
 type CommitStepTimes struct {
@@ -133,6 +136,11 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	maxGas := state.ConsensusParams.Block.MaxGas
 
 	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+	if max := state.OCConsensusParams.MaxEvidencePerBlock; max > 0 && int64(len(evidence)) > max {
+		evidence = evidence[:max]
+		evData := types.EvidenceData{Evidence: evidence}
+		evSize = evData.ByteSize()
+	}
 
 	// Fetch a limited amount of valid txs
 	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
@@ -235,6 +243,16 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	fail.Fail() // XXX
 
+	// Record the validator set change before saving the state, so that it's
+	// never missing from the log. If we crash before saving the state, the
+	// block is replayed and the change appended again.
+	if blockExec.valSetChangeLog != nil && len(validatorUpdates) > 0 {
+		change := ValidatorSetChange{Height: block.Height, Updates: validatorUpdates}
+		if err := blockExec.valSetChangeLog.Append(change); err != nil {
+			return state, 0, fmt.Errorf("failed appending to validator set change log: %w", err)
+		}
+	}
+
 	// Update the app hash and save the state.
 	state.AppHash = appHash
 	if err := blockExec.store.Save(state); err != nil {
@@ -511,7 +529,7 @@ func updateState(
 	// Update the validator set with the latest abciResponses.
 	lastHeightValsChanged := state.LastHeightValidatorsChanged
 	if len(validatorUpdates) > 0 {
-		err := nValSet.UpdateWithChangeSet(validatorUpdates)
+		err := nValSet.UpdateWithChangeSetMaxSize(validatorUpdates, int(state.OCConsensusParams.MaxValidators))
 		if err != nil {
 			return state, fmt.Errorf("error changing validator set: %v", err)
 		}
@@ -2,6 +2,7 @@ package state_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -279,6 +280,45 @@ func TestBeginBlockByzantineValidators(t *testing.T) {
 	assert.Equal(t, abciEv, app.ByzantineValidators)
 }
 
+func TestCreateProposalBlockMaxEvidencePerBlock(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	proposer := state.Validators.Validators[0]
+	privVal := privVals[proposer.Address.String()]
+
+	evidenceTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ev := []types.Evidence{
+		types.NewMockDuplicateVoteEvidenceWithValidator(1, evidenceTime, privVal, state.ChainID),
+		types.NewMockDuplicateVoteEvidenceWithValidator(1, evidenceTime, privVal, state.ChainID),
+		types.NewMockDuplicateVoteEvidenceWithValidator(1, evidenceTime, privVal, state.ChainID),
+	}
+	evpool := &mocks.EvidencePool{}
+	evpool.On("PendingEvidence", mock.AnythingOfType("int64")).Return(ev, int64(100))
+
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, evpool)
+
+	commit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	proof, err := privVal.GenerateVRFProof(state.MakeHashMessage(0))
+	require.NoError(t, err)
+
+	// no maximum by default
+	block, _ := blockExec.CreateProposalBlock(1, state, commit, proposer.Address, 0, proof, 0)
+	assert.Len(t, block.Evidence.Evidence, 3)
+
+	state.OCConsensusParams.MaxEvidencePerBlock = 2
+	block, _ = blockExec.CreateProposalBlock(1, state, commit, proposer.Address, 0, proof, 0)
+	assert.Equal(t, types.EvidenceList(ev[:2]), block.Evidence.Evidence)
+	assert.NoError(t, types.ValidateEvidenceLimit(block.Evidence.Evidence, state.ConsensusParams, state.OCConsensusParams))
+}
+
 func TestValidateValidatorUpdates(t *testing.T) {
 	pubkey1 := ed25519.GenPrivKey().PubKey()
 	pubkey2 := ed25519.GenPrivKey().PubKey()
@@ -514,6 +554,88 @@ func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 	assert.NotEmpty(t, state.NextValidators.Validators)
 }
 
+// TestEndBlockValidatorUpdatesExceedingMaxValidators checks that validator
+// updates which would exceed the max_validators consensus param are rejected
+// and NextValidators is not updated
+func TestEndBlockValidatorUpdatesExceedingMaxValidators(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	state.OCConsensusParams.MaxValidators = 1
+	stateStore := sm.NewStore(stateDB)
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+	)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	pubkey := ed25519.GenPrivKey().PubKey()
+	pk, err := cryptoenc.PubKeyToProto(pubkey)
+	require.NoError(t, err)
+	// Add a second validator
+	app.ValidatorUpdates = []abci.ValidatorUpdate{
+		{PubKey: pk, Power: 10},
+	}
+
+	nextVals := state.NextValidators.Copy()
+	_, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	assert.Error(t, err)
+	assert.Equal(t, nextVals, state.NextValidators)
+}
+
+type failingValidatorSetChangeLog struct{}
+
+func (failingValidatorSetChangeLog) Append(sm.ValidatorSetChange) error {
+	return errors.New("disk full")
+}
+
+// TestEndBlockValidatorUpdatesChangeLogFailure checks that a validator set
+// change which can't be recorded fails the block before the state is saved
+func TestEndBlockValidatorUpdatesChangeLogFailure(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		mmock.Mempool{},
+		sm.EmptyEvidencePool{},
+		sm.BlockExecutorWithValidatorSetChangeLog(failingValidatorSetChangeLog{}),
+	)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	pk, err := cryptoenc.PubKeyToProto(ed25519.GenPrivKey().PubKey())
+	require.NoError(t, err)
+	app.ValidatorUpdates = []abci.ValidatorUpdate{
+		{PubKey: pk, Power: 10},
+	}
+
+	_, _, err = blockExec.ApplyBlock(state, blockID, block, nil)
+	assert.Error(t, err)
+	saved, err := stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, state.LastBlockHeight, saved.LastBlockHeight)
+}
+
 func makeBlockID(hash []byte, partSetSize uint32, partSetHash []byte) types.BlockID {
 	var (
 		h   = make([]byte, tmhash.Size)
@@ -2,6 +2,7 @@ package state
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"os"
@@ -12,6 +13,8 @@ import (
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 
 	"github.com/line/ostracon/crypto"
+	"github.com/line/ostracon/crypto/tmhash"
+	"github.com/line/ostracon/crypto/vrf"
 	ocstate "github.com/line/ostracon/proto/ostracon/state"
 	"github.com/line/ostracon/types"
 	tmtime "github.com/line/ostracon/types/time"
@@ -23,6 +26,37 @@ var (
 	stateKey = []byte("stateKey")
 )
 
+// vrfSeedMixingTag separates the mixed seeds from any other hash.
+var vrfSeedMixingTag = []byte("ostracon/vrf-seed")
+
+// MixSeed returns the seed of the VRF message of the given round following the
+// block of the given height, whose VRF output is prevOutput, built as selected
+// by the VRF seed mixing of the params.
+func MixSeed(params types.OCConsensusParams, prevOutput vrf.Output, height int64, round int32) []byte {
+	if !params.VRFSeedMixing {
+		return types.MakeRoundHash(prevOutput, height, round)
+	}
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b, uint64(height))
+	binary.BigEndian.PutUint32(b[8:], uint32(round))
+	hash := tmhash.New()
+	hash.Write(vrfSeedMixingTag) //nolint:errcheck // never fails
+	hash.Write(b)                //nolint:errcheck // never fails
+	hash.Write(prevOutput)       //nolint:errcheck // never fails
+	return hash.Sum(nil)
+}
+
+// VRFMessage returns the message the proposer of the given round following the
+// block of the given height proves with its VRF: the seed (MixSeed), tagged
+// with the VRF message domain of the params, if any.
+func VRFMessage(params types.OCConsensusParams, prevOutput vrf.Output, height int64, round int32) []byte {
+	message := MixSeed(params, prevOutput, height, round)
+	if params.VRFMessageDomain == "" {
+		return message
+	}
+	return tmhash.Sum(append([]byte(params.VRFMessageDomain), message...))
+}
+
 //-----------------------------------------------------------------------------
 
 // InitStateVersion sets the Consensus.Block and Software versions,
@@ -77,6 +111,9 @@ type State struct {
 	ConsensusParams                  tmproto.ConsensusParams
 	LastHeightConsensusParamsChanged int64
 
+	// Ostracon specific consensus parameters, set at genesis.
+	OCConsensusParams types.OCConsensusParams
+
 	// Merkle root of the results from executing prev block
 	LastResultsHash []byte
 
@@ -84,8 +121,10 @@ type State struct {
 	AppHash []byte
 }
 
+// MakeHashMessage returns the message the proposer of the given round of the
+// next height proves with its VRF (see VRFMessage).
 func (state State) MakeHashMessage(round int32) []byte {
-	return types.MakeRoundHash(state.LastProofHash, state.LastBlockHeight, round)
+	return VRFMessage(state.OCConsensusParams, state.LastProofHash, state.LastBlockHeight, round)
 }
 
 // Copy makes a copy of the State for mutating.
@@ -110,6 +149,8 @@ func (state State) Copy() State {
 		ConsensusParams:                  state.ConsensusParams,
 		LastHeightConsensusParamsChanged: state.LastHeightConsensusParamsChanged,
 
+		OCConsensusParams: state.OCConsensusParams,
+
 		AppHash: state.AppHash,
 
 		LastResultsHash: state.LastResultsHash,
@@ -183,6 +224,13 @@ func (state *State) ToProto() (*ocstate.State, error) {
 	sm.AppHash = state.AppHash
 
 	sm.LastProofHash = state.LastProofHash
+	sm.VRFMessageDomain = state.OCConsensusParams.VRFMessageDomain
+	sm.VRFSeedMixing = state.OCConsensusParams.VRFSeedMixing
+	sm.ProposerSelectionPrecision = state.OCConsensusParams.ProposerSelectionPrecision
+	sm.MaxEvidencePerBlock = state.OCConsensusParams.MaxEvidencePerBlock
+	sm.ProposerTieBreak = int32(state.OCConsensusParams.ProposerTieBreak)
+	sm.MaxValidators = state.OCConsensusParams.MaxValidators
+	sm.CommitTimestampTolerance = int64(state.OCConsensusParams.CommitTimestampTolerance)
 
 	return sm, nil
 }
@@ -236,6 +284,13 @@ func FromProto(pb *ocstate.State) (*State, error) { //nolint:golint
 	state.AppHash = pb.AppHash
 
 	state.LastProofHash = pb.LastProofHash
+	state.OCConsensusParams.VRFMessageDomain = pb.VRFMessageDomain
+	state.OCConsensusParams.VRFSeedMixing = pb.VRFSeedMixing
+	state.OCConsensusParams.ProposerSelectionPrecision = pb.ProposerSelectionPrecision
+	state.OCConsensusParams.MaxEvidencePerBlock = pb.MaxEvidencePerBlock
+	state.OCConsensusParams.ProposerTieBreak = types.ProposerTieBreak(pb.ProposerTieBreak)
+	state.OCConsensusParams.MaxValidators = pb.MaxValidators
+	state.OCConsensusParams.CommitTimestampTolerance = time.Duration(pb.CommitTimestampTolerance)
 
 	return state, nil
 }
@@ -356,6 +411,11 @@ func MakeGenesisState(genDoc *types.GenesisDoc) (State, error) {
 		nextValidatorSet = types.NewValidatorSet(validators)
 	}
 
+	ocParams := types.DefaultOCConsensusParams()
+	if genDoc.OCConsensusParams != nil {
+		ocParams = genDoc.OCConsensusParams
+	}
+
 	return State{
 		Version:       InitStateVersion,
 		ChainID:       genDoc.ChainID,
@@ -376,6 +436,8 @@ func MakeGenesisState(genDoc *types.GenesisDoc) (State, error) {
 		ConsensusParams:                  *genDoc.ConsensusParams,
 		LastHeightConsensusParamsChanged: genDoc.InitialHeight,
 
+		OCConsensusParams: *ocParams,
+
 		AppHash: genDoc.AppHash,
 	}, nil
 }
@@ -20,6 +20,8 @@ import (
 	cfg "github.com/line/ostracon/config"
 	"github.com/line/ostracon/crypto/ed25519"
 	cryptoenc "github.com/line/ostracon/crypto/encoding"
+	"github.com/line/ostracon/crypto/tmhash"
+	"github.com/line/ostracon/crypto/vrf"
 	tmrand "github.com/line/ostracon/libs/rand"
 	tmstate "github.com/line/ostracon/proto/ostracon/state"
 	sm "github.com/line/ostracon/state"
@@ -75,6 +77,19 @@ func TestMakeGenesisStateNilValidators(t *testing.T) {
 	require.Equal(t, 0, len(state.NextValidators.Validators))
 }
 
+// TestMakeGenesisStateOCConsensusParams tests that the ostracon consensus params
+// of the genesis file are copied to the state, or defaulted if absent.
+func TestMakeGenesisStateOCConsensusParams(t *testing.T) {
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{ChainID: "dummy"})
+	require.NoError(t, err)
+	require.Equal(t, *types.DefaultOCConsensusParams(), state.OCConsensusParams)
+
+	params := types.OCConsensusParams{VRFMessageDomain: "domain"}
+	state, err = sm.MakeGenesisState(&types.GenesisDoc{ChainID: "dummy", OCConsensusParams: &params})
+	require.NoError(t, err)
+	require.Equal(t, params, state.OCConsensusParams)
+}
+
 // TestStateSaveLoad tests saving and loading State from a db.
 func TestStateSaveLoad(t *testing.T) {
 	tearDown, stateDB, state := setupTestCase(t)
@@ -1105,6 +1120,15 @@ func TestStateProto(t *testing.T) {
 	tearDown, _, state := setupTestCase(t)
 	defer tearDown(t)
 
+	withOCParams := state.Copy()
+	withOCParams.OCConsensusParams.VRFMessageDomain = "domain"
+	withOCParams.OCConsensusParams.VRFSeedMixing = true
+	withOCParams.OCConsensusParams.ProposerSelectionPrecision = 2 * 63
+	withOCParams.OCConsensusParams.MaxEvidencePerBlock = 10
+	withOCParams.OCConsensusParams.ProposerTieBreak = types.ProposerTieBreakByPubKeyHash
+	withOCParams.OCConsensusParams.MaxValidators = 100
+	withOCParams.OCConsensusParams.CommitTimestampTolerance = time.Minute
+
 	tc := []struct {
 		testName string
 		state    *sm.State
@@ -1114,6 +1138,7 @@ func TestStateProto(t *testing.T) {
 		{"empty state", &sm.State{}, true, false},
 		{"nil failure state", nil, false, false},
 		{"success state", &state, true, true},
+		{"success state with ostracon consensus params", &withOCParams, true, true},
 	}
 
 	for _, tt := range tc {
@@ -1151,6 +1176,61 @@ func TestState_MakeHashMessage(t *testing.T) {
 	require.False(t, bytes.Equal(message2, message3))
 }
 
+func TestMixSeed(t *testing.T) {
+	output := vrf.Output(tmhash.Sum([]byte("previous output")))
+
+	// the legacy mode reproduces the round hashes
+	var params types.OCConsensusParams
+	legacy := sm.MixSeed(params, output, 10, 0)
+	require.Equal(t, types.MakeRoundHash(output, 10, 0), legacy)
+	require.Equal(t,
+		"5F3CF0EFEB50DF038F4A1970BA91D790DE33569C7A591B7342751306F6CF91FF", fmt.Sprintf("%X", legacy))
+
+	params.VRFSeedMixing = true
+	mixed := sm.MixSeed(params, output, 10, 0)
+	require.False(t, bytes.Equal(legacy, mixed))
+	require.Equal(t, mixed, sm.MixSeed(params, output, 10, 0))
+	require.False(t, bytes.Equal(mixed, sm.MixSeed(params, output, 11, 0)))
+	require.False(t, bytes.Equal(mixed, sm.MixSeed(params, output, 10, 1)))
+	require.False(t, bytes.Equal(mixed, sm.MixSeed(params, tmhash.Sum(output), 10, 0)))
+
+	// the messages are made of the mixed seeds of the state's params
+	_, _, state := setupTestCase(t)
+	state.OCConsensusParams.VRFSeedMixing = true
+	require.Equal(t, sm.MixSeed(params, state.LastProofHash, state.LastBlockHeight, 2), state.MakeHashMessage(2))
+}
+
+func TestState_MakeHashMessageDomain(t *testing.T) {
+	_, _, state := setupTestCase(t)
+	privVal := makePrivVal()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	// no tag by default
+	untagged := state.MakeHashMessage(0)
+	require.Equal(t, types.MakeRoundHash(state.LastProofHash, state.LastBlockHeight, 0), untagged)
+
+	state.OCConsensusParams.VRFMessageDomain = "domain-a"
+	messageA := state.MakeHashMessage(0)
+	proofA, err := privVal.GenerateVRFProof(messageA)
+	require.NoError(t, err)
+
+	state.OCConsensusParams.VRFMessageDomain = "domain-b"
+	messageB := state.MakeHashMessage(0)
+	proofB, err := privVal.GenerateVRFProof(messageB)
+	require.NoError(t, err)
+
+	require.False(t, bytes.Equal(untagged, messageA))
+	require.False(t, bytes.Equal(messageA, messageB))
+	require.False(t, bytes.Equal(proofA, proofB))
+
+	// a proof made for a domain doesn't verify for another one
+	_, err = pubKey.VRFVerify(proofA, messageA)
+	require.NoError(t, err)
+	_, err = pubKey.VRFVerify(proofA, messageB)
+	require.Error(t, err)
+}
+
 func TestMedianTime(t *testing.T) {
 	now := tmtime.Now()
 	cases := []struct {
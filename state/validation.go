@@ -90,8 +90,9 @@ func validateBlock(state State, round int32, block *types.Block) error {
 		}
 	} else {
 		// LastCommit.Signatures length is checked in VerifyCommit.
-		if err := state.LastValidators.VerifyCommit(
-			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
+		if err := state.LastValidators.VerifyCommitWithTimestampTolerance(
+			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit,
+			state.LastBlockTime, state.OCConsensusParams.CommitTimestampTolerance); err != nil {
 			return err
 		}
 	}
@@ -142,9 +143,9 @@ func validateBlock(state State, round int32, block *types.Block) error {
 			block.Height, state.InitialHeight)
 	}
 
-	// Check evidence doesn't exceed the limit amount of bytes.
-	if max, got := state.ConsensusParams.Evidence.MaxBytes, block.Evidence.ByteSize(); got > max {
-		return types.NewErrEvidenceOverflow(max, got)
+	// Check evidence doesn't exceed the limit amount of evidence and bytes.
+	if err := types.ValidateEvidenceLimit(block.Evidence.Evidence, state.ConsensusParams, state.OCConsensusParams); err != nil {
+		return err
 	}
 
 	// validate round
@@ -155,7 +156,8 @@ func validateBlock(state State, round int32, block *types.Block) error {
 	}
 
 	// validate proposer
-	proposer := state.Validators.SelectProposer(state.LastProofHash, block.Height, block.Round)
+	proposer := state.Validators.SelectProposerWithParams(
+		state.OCConsensusParams, state.LastProofHash, block.Height, block.Round)
 	if !bytes.Equal(block.ProposerAddress.Bytes(), proposer.Address.Bytes()) {
 		return fmt.Errorf("block.ProposerAddress, %X, is not the proposer %X",
 			block.ProposerAddress,
@@ -222,6 +222,54 @@ func TestValidateBlockCommit(t *testing.T) {
 	}
 }
 
+func TestValidateBlockCommitTimestampTolerance(t *testing.T) {
+	proxyApp := newTestApp()
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	state, stateDB, privVals := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockExec := sm.NewBlockExecutor(
+		stateStore,
+		log.TestingLogger(),
+		proxyApp.Consensus(),
+		memmock.Mempool{},
+		sm.EmptyEvidencePool{},
+	)
+	lastCommit := types.NewCommit(0, 0, types.BlockID{}, nil)
+
+	// the first block has the genesis time, far from the timestamps of its
+	// commit, so the tolerance is only set from height 3
+	var blockID types.BlockID
+	for height := int64(1); height <= 2; height++ {
+		proposerAddr := state.Validators.SelectProposer(state.LastProofHash, height, 0).Address
+		var err error
+		state, blockID, lastCommit, err = makeAndCommitGoodBlock(
+			state, height, lastCommit, proposerAddr, blockExec, privVals, nil)
+		require.NoError(t, err, "height %d", height)
+	}
+	state.OCConsensusParams.CommitTimestampTolerance = time.Hour
+
+	height := int64(3)
+	proposerAddr := state.Validators.SelectProposer(state.LastProofHash, height, 0).Address
+	message := state.MakeHashMessage(0)
+	proof, _ := privVals[proposerAddr.String()].GenerateVRFProof(message)
+
+	// a vote of a far future timestamp is rejected
+	futureVote, err := types.MakeVote(height-1, blockID, state.LastValidators,
+		privVals[proposerAddr.String()], chainID, state.LastBlockTime.Add(24*time.Hour))
+	require.NoError(t, err)
+	futureCommit := types.NewCommit(height-1, 0, blockID, []types.CommitSig{futureVote.CommitSig()})
+	block, _ := state.MakeBlock(height, makeTxs(height), futureCommit, nil, proposerAddr, 0, proof)
+	err = blockExec.ValidateBlock(state, 0, block)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "deviates from block time")
+
+	// the votes within the tolerance are fine
+	block, _ = state.MakeBlock(height, makeTxs(height), lastCommit, nil, proposerAddr, 0, proof)
+	assert.NoError(t, blockExec.ValidateBlock(state, 0, block))
+}
+
 func TestValidateBlockEvidence(t *testing.T) {
 	proxyApp := newTestApp()
 	require.NoError(t, proxyApp.Start())
@@ -0,0 +1,140 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	tmjson "github.com/line/ostracon/libs/json"
+	tmsync "github.com/line/ostracon/libs/sync"
+	"github.com/line/ostracon/types"
+)
+
+// ValidatorSetChange is a single entry of a ValidatorSetChangeLog: the
+// validator updates returned by EndBlock of the block at Height. As with
+// state.LastHeightValidatorsChanged, the updates take effect at Height + 2.
+// An update with zero voting power removes the validator.
+type ValidatorSetChange struct {
+	Height  int64              `json:"height"`
+	Updates []*types.Validator `json:"updates"`
+}
+
+// ValidatorSetChangeLog is an append-only sink of validator set changes.
+// The BlockExecutor appends to it once per block that changes the validator
+// set, before the new state is saved, and fails the block if it can't.
+type ValidatorSetChangeLog interface {
+	Append(change ValidatorSetChange) error
+}
+
+// BlockExecutorWithValidatorSetChangeLog sets the log the BlockExecutor
+// records validator set changes to.
+func BlockExecutorWithValidatorSetChangeLog(changeLog ValidatorSetChangeLog) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.valSetChangeLog = changeLog
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// FileValidatorSetChangeLog is a ValidatorSetChangeLog which appends each
+// change to a file as a line of JSON.
+type FileValidatorSetChangeLog struct {
+	mtx  tmsync.Mutex
+	file *os.File
+}
+
+var _ ValidatorSetChangeLog = (*FileValidatorSetChangeLog)(nil)
+
+// OpenFileValidatorSetChangeLog opens (creating it if needed) the change log
+// at the given path for appending.
+func OpenFileValidatorSetChangeLog(path string) (*FileValidatorSetChangeLog, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileValidatorSetChangeLog{file: file}, nil
+}
+
+// Append writes the change to the end of the file and syncs it to disk.
+func (l *FileValidatorSetChangeLog) Append(change ValidatorSetChange) error {
+	bz, err := tmjson.Marshal(change)
+	if err != nil {
+		return err
+	}
+	bz = append(bz, '\n')
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if _, err := l.file.Write(bz); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+// Close closes the underlying file.
+func (l *FileValidatorSetChangeLog) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.file.Close()
+}
+
+//-----------------------------------------------------------------------------
+
+// ReadValidatorSetChanges reads all changes written by a
+// FileValidatorSetChangeLog, in the order they were appended.
+//
+// A node that crashes after appending a change but before saving the new state
+// replays the block on restart and appends the same change again, so an entry
+// whose height is not above the previous entry's is skipped.
+func ReadValidatorSetChanges(r io.Reader) ([]ValidatorSetChange, error) {
+	var (
+		changes    []ValidatorSetChange
+		lastHeight int64
+		br         = bufio.NewReader(r)
+	)
+	for line := 1; ; line++ {
+		bz, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if bz = bytes.TrimSpace(bz); len(bz) > 0 {
+			var change ValidatorSetChange
+			if err := tmjson.Unmarshal(bz, &change); err != nil {
+				return nil, fmt.Errorf("invalid validator set change at line %d: %w", line, err)
+			}
+			if change.Height > lastHeight {
+				changes = append(changes, change)
+				lastHeight = change.Height
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return changes, nil
+		}
+	}
+}
+
+// ReplayValidatorSetChanges applies the changes in effect at the given height
+// (i.e. those with change.Height + 2 <= height) to a copy of the initial
+// validator set, typically the genesis one, and returns the result.
+//
+// The log only records membership and voting power, so proposer priorities
+// of the returned set are not the ones the chain had at that height.
+func ReplayValidatorSetChanges(
+	initial *types.ValidatorSet,
+	changes []ValidatorSetChange,
+	height int64,
+) (*types.ValidatorSet, error) {
+	valSet := initial.Copy()
+	for _, change := range changes {
+		if change.Height+2 > height {
+			break
+		}
+		if err := valSet.UpdateWithChangeSet(change.Updates); err != nil {
+			return nil, fmt.Errorf("error replaying validator set change at height %d: %w", change.Height, err)
+		}
+	}
+	return valSet, nil
+}
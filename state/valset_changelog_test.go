@@ -0,0 +1,69 @@
+package state_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/line/ostracon/crypto/ed25519"
+	sm "github.com/line/ostracon/state"
+	"github.com/line/ostracon/types"
+)
+
+func TestValidatorSetChangeLogReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "valset_changes.log")
+	changeLog, err := sm.OpenFileValidatorSetChangeLog(path)
+	require.NoError(t, err)
+
+	genesis, _ := types.RandValidatorSet(4, 10)
+	valSet := genesis.Copy()
+	// the validator set in effect at each height, keyed by height
+	history := map[int64][]byte{1: genesis.Hash(), 2: genesis.Hash()}
+
+	for height := int64(1); height <= 20; height++ {
+		if height%3 == 0 {
+			var updates []*types.Validator
+			switch height % 2 {
+			case 0:
+				// remove a validator and add a new one
+				updates = append(updates,
+					types.NewValidator(valSet.Validators[0].PubKey, 0),
+					types.NewValidator(ed25519.GenPrivKey().PubKey(), height))
+			default:
+				// change a voting power
+				updates = append(updates, types.NewValidator(valSet.Validators[1].PubKey, height*2))
+			}
+			change := sm.ValidatorSetChange{Height: height, Updates: updates}
+			require.NoError(t, changeLog.Append(change))
+			// the same change appended again, as after a crash and replay
+			if height == 9 {
+				require.NoError(t, changeLog.Append(change))
+			}
+			require.NoError(t, valSet.UpdateWithChangeSet(updates))
+		}
+		history[height+2] = valSet.Hash()
+	}
+	require.NoError(t, changeLog.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	changes, err := sm.ReadValidatorSetChanges(file)
+	require.NoError(t, err)
+	assert.Len(t, changes, 6)
+
+	for height, hash := range history {
+		replayed, err := sm.ReplayValidatorSetChanges(genesis, changes, height)
+		require.NoError(t, err)
+		assert.Equal(t, hash, replayed.Hash(), "height %d", height)
+	}
+
+	// replaying everything reproduces the final set
+	final, err := sm.ReplayValidatorSetChanges(genesis, changes, 22)
+	require.NoError(t, err)
+	assert.Equal(t, valSet.Hash(), final.Hash())
+	assert.Equal(t, valSet.TotalVotingPower(), final.TotalVotingPower())
+}
@@ -81,6 +81,9 @@ type Config struct {
 	PrepareProposalDelay time.Duration `toml:"prepare_proposal_delay"`
 	ProcessProposalDelay time.Duration `toml:"process_proposal_delay"`
 	CheckTxDelay         time.Duration `toml:"check_tx_delay"`
+	// CommitDelay is added to each Commit, i.e. each height, delaying the
+	// start of the next height.
+	CommitDelay time.Duration `toml:"commit_delay"`
 	// TODO: add vote extension and finalize block delays once completed (@cmwaters)
 }
 
@@ -195,6 +198,10 @@ func (app *Application) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock
 
 // Commit implements ABCI.
 func (app *Application) Commit() abci.ResponseCommit {
+	if app.cfg.CommitDelay != 0 {
+		time.Sleep(app.cfg.CommitDelay)
+	}
+
 	height, hash, err := app.state.Commit()
 	if err != nil {
 		panic(err)
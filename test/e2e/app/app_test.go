@@ -0,0 +1,33 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApplicationCommitDelay(t *testing.T) {
+	const (
+		heights = 3
+		delay   = 50 * time.Millisecond
+	)
+	cfg := DefaultConfig(t.TempDir())
+	cfg.SnapshotInterval = 0
+	cfg.CommitDelay = delay
+	app, err := NewApplication(cfg)
+	require.NoError(t, err)
+
+	start := time.Now()
+	for height := int64(1); height <= heights; height++ {
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), heights*delay)
+
+	// the application still makes progress
+	info := app.Info(abci.RequestInfo{})
+	assert.EqualValues(t, heights, info.LastBlockHeight)
+}
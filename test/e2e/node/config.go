@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/BurntSushi/toml"
 
@@ -24,6 +25,11 @@ type Config struct {
 	PrivValKey       string                      `toml:"privval_key"`
 	PrivValState     string                      `toml:"privval_state"`
 	KeyType          string                      `toml:"key_type"`
+
+	PrepareProposalDelay time.Duration `toml:"prepare_proposal_delay"`
+	ProcessProposalDelay time.Duration `toml:"process_proposal_delay"`
+	CheckTxDelay         time.Duration `toml:"check_tx_delay"`
+	CommitDelay          time.Duration `toml:"commit_delay"`
 }
 
 // App extracts out the application specific configuration parameters
@@ -35,6 +41,11 @@ func (cfg *Config) App() *app.Config {
 		KeyType:          cfg.KeyType,
 		ValidatorUpdates: cfg.ValidatorUpdates,
 		PersistInterval:  cfg.PersistInterval,
+
+		PrepareProposalDelay: cfg.PrepareProposalDelay,
+		ProcessProposalDelay: cfg.ProcessProposalDelay,
+		CheckTxDelay:         cfg.CheckTxDelay,
+		CommitDelay:          cfg.CommitDelay,
 	}
 }
 
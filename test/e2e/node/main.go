@@ -28,6 +28,7 @@ import (
 	rpcserver "github.com/line/ostracon/rpc/jsonrpc/server"
 	"github.com/line/ostracon/test/e2e/app"
 	e2e "github.com/line/ostracon/test/e2e/pkg"
+	"github.com/line/ostracon/types"
 )
 
 var logger = log.NewOCLogger(log.NewSyncWriter(os.Stdout))
@@ -167,6 +168,7 @@ func startLightClient(cfg *Config) error {
 		providers[1:],
 		dbs.New(lightDB, "light"),
 		light.Logger(nodeLogger),
+		light.ProviderRetry(3, time.Second),
 	)
 	if err != nil {
 		return err
@@ -176,6 +178,9 @@ func startLightClient(cfg *Config) error {
 	rpccfg.MaxBodyBytes = tmcfg.RPC.MaxBodyBytes
 	rpccfg.MaxHeaderBytes = tmcfg.RPC.MaxHeaderBytes
 	rpccfg.MaxOpenConnections = tmcfg.RPC.MaxOpenConnections
+	rpccfg.ListenBacklog = tmcfg.RPC.ListenBacklog
+	rpccfg.MaxSubscriptionsPerConnection = tmcfg.RPC.MaxSubscriptionsPerClient
+	rpccfg.MaxSubscriptions = tmcfg.RPC.MaxSubscriptions
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
@@ -201,8 +206,14 @@ func startLightClient(cfg *Config) error {
 // startSigner starts a signer server connecting to the given endpoint.
 func startSigner(cfg *Config) error {
 	filePV := privval.LoadFilePV(cfg.PrivValKey, cfg.PrivValState)
+	if err := types.SelfTestPrivValidator(filePV); err != nil {
+		return fmt.Errorf("private validator self-test failed: %w", err)
+	}
 
-	protocol, address := tmnet.ProtocolAndAddress(cfg.PrivValServer)
+	protocol, address, err := tmnet.ParseEndpoint(cfg.PrivValServer)
+	if err != nil {
+		return fmt.Errorf("invalid privval server: %w", err)
+	}
 	var dialFn privval.SocketDialer
 	switch protocol {
 	case "tcp":
@@ -216,7 +227,7 @@ func startSigner(cfg *Config) error {
 	endpoint := privval.NewSignerDialerEndpoint(logger, dialFn,
 		privval.SignerDialerEndpointRetryWaitInterval(1*time.Second),
 		privval.SignerDialerEndpointConnRetries(100))
-	err := privval.NewSignerServer(endpoint, cfg.ChainID, filePV).Start()
+	err = privval.NewSignerServer(endpoint, cfg.ChainID, filePV).Start()
 	if err != nil {
 		return err
 	}
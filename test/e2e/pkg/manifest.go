@@ -63,6 +63,7 @@ type Manifest struct {
 	PrepareProposalDelay time.Duration `toml:"prepare_proposal_delay"`
 	ProcessProposalDelay time.Duration `toml:"process_proposal_delay"`
 	CheckTxDelay         time.Duration `toml:"check_tx_delay"`
+	CommitDelay          time.Duration `toml:"commit_delay"`
 	// TODO: add vote extension and finalize block delay (@cmwaters)
 
 	LoadTxSizeBytes   int `toml:"load_tx_size_bytes"`
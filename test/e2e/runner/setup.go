@@ -263,6 +263,7 @@ func MakeAppConfig(node *e2e.Node) ([]byte, error) {
 		"prepare_proposal_delay": node.Testnet.PrepareProposalDelay,
 		"process_proposal_delay": node.Testnet.ProcessProposalDelay,
 		"check_tx_delay":         node.Testnet.CheckTxDelay,
+		"commit_delay":           node.Testnet.CommitDelay,
 	}
 	switch node.ABCIProtocol {
 	case e2e.ProtocolUNIX:
@@ -0,0 +1,13 @@
+package validatorset
+
+import (
+	"github.com/line/ostracon/types"
+)
+
+func Fuzz(data []byte) int {
+	if err := types.FuzzUpdateWithChangeSet(data); err != nil {
+		return 0
+	}
+
+	return 1
+}
@@ -360,6 +360,28 @@ func MaxDataBytesNoEvidence(maxBytes int64, valsCount int) int64 {
 	return maxDataBytes
 }
 
+// EstimateMaxDataBytes returns the maximum size of the data of a block of at
+// most maxBytes, with the last commit of numValidators validators. If
+// includeEvidence is true, the size of the evidence is reserved as well, up to
+// the default EvidenceParams.MaxBytes; otherwise, it's the same as
+// MaxDataBytesNoEvidence. Unlike those, it returns 0 rather than panicking
+// if maxBytes can't accommodate a block.
+func EstimateMaxDataBytes(maxBytes int64, numValidators int, includeEvidence bool) int64 {
+	var evidenceBytes int64
+	if includeEvidence {
+		evidenceBytes = DefaultEvidenceParams().MaxBytes
+	}
+	overhead := MaxOverheadForBlock +
+		MaxHeaderBytes +
+		MaxEntropyBytes +
+		MaxCommitBytes(numValidators) +
+		evidenceBytes
+	if maxBytes < overhead {
+		return 0
+	}
+	return MaxDataBytes(maxBytes, evidenceBytes, numValidators)
+}
+
 //-----------------------------------------------------------------------------
 
 // Header defines the structure of an Ostracon block header.
@@ -628,6 +650,20 @@ const (
 	BlockIDFlagNil
 )
 
+// String returns the name of the flag.
+func (f BlockIDFlag) String() string {
+	switch f {
+	case BlockIDFlagAbsent:
+		return "Absent"
+	case BlockIDFlagCommit:
+		return "Commit"
+	case BlockIDFlagNil:
+		return "Nil"
+	default:
+		return fmt.Sprintf("Unknown(%d)", byte(f))
+	}
+}
+
 // MaxCommitOverheadBytes is max size of commit without any commitSigs -> 82 for BlockID, 8 for Height, 4 for Round.
 // NOTE: 🏺This size is for the ProtocolBuffers representation of Commit without CommitSig. Therefore, it includes
 // the overhead of ProtocolBuffers in addition to the above number.
@@ -693,7 +729,12 @@ func (cs CommitSig) Absent() bool {
 // 2. first 6 bytes of validator address
 // 3. block ID flag
 // 4. timestamp
+//
+// An absent signature, which has none of them, is "CommitSig{Absent}".
 func (cs CommitSig) String() string {
+	if cs.BlockIDFlag == BlockIDFlagAbsent {
+		return "CommitSig{Absent}"
+	}
 	return fmt.Sprintf("CommitSig{%X by %X on %v @ %s}",
 		tmbytes.Fingerprint(cs.Signature),
 		tmbytes.Fingerprint(cs.ValidatorAddress),
@@ -815,6 +856,38 @@ func NewCommit(height int64, round int32, blockID BlockID, commitSigs []CommitSi
 	}
 }
 
+// CommitFromVotes builds the Commit for blockID of a set of totalValidators
+// validators from their precommits, e.g. recovered from the WAL. Each vote is
+// placed at its ValidatorIndex and the validators without a vote are marked
+// absent. A vote must be a precommit of the given height and round, for
+// blockID or nil. The signatures are not verified.
+func CommitFromVotes(height int64, round int32, blockID BlockID, votes []*Vote, totalValidators int) (*Commit, error) {
+	commitSigs := make([]CommitSig, totalValidators)
+	for i := range commitSigs {
+		commitSigs[i] = NewCommitSigAbsent()
+	}
+	for i, vote := range votes {
+		switch {
+		case vote == nil:
+			return nil, fmt.Errorf("vote #%d is nil", i)
+		case vote.Type != tmproto.PrecommitType:
+			return nil, fmt.Errorf("vote #%d is not a precommit: %v", i, vote.Type)
+		case vote.Height != height || vote.Round != round:
+			return nil, fmt.Errorf("vote #%d is for height/round %d/%d, expected %d/%d",
+				i, vote.Height, vote.Round, height, round)
+		case !vote.BlockID.Equals(blockID) && !vote.BlockID.IsZero():
+			return nil, fmt.Errorf("vote #%d is for block %v, expected %v or nil", i, vote.BlockID, blockID)
+		case vote.ValidatorIndex < 0 || int(vote.ValidatorIndex) >= totalValidators:
+			return nil, fmt.Errorf("vote #%d has validator index %d, out of range [0, %d)",
+				i, vote.ValidatorIndex, totalValidators)
+		case !commitSigs[vote.ValidatorIndex].Absent():
+			return nil, fmt.Errorf("vote #%d duplicates the vote of validator index %d", i, vote.ValidatorIndex)
+		}
+		commitSigs[vote.ValidatorIndex] = vote.CommitSig()
+	}
+	return NewCommit(height, round, blockID, commitSigs), nil
+}
+
 // CommitToVoteSet constructs a VoteSet from the Commit and validator set.
 // Panics if signatures from the commit can't be added to the voteset.
 // Inverse of VoteSet.MakeCommit().
@@ -935,10 +1008,20 @@ func (commit *Commit) ValidateBasic() error {
 		if len(commit.Signatures) == 0 {
 			return errors.New("no signatures in commit")
 		}
+		seenVals := make(map[string]int, len(commit.Signatures)) // validator address -> commit index
 		for i, commitSig := range commit.Signatures {
 			if err := commitSig.ValidateBasic(); err != nil {
 				return fmt.Errorf("wrong CommitSig #%d: %v", i, err)
 			}
+			if commitSig.Absent() {
+				continue
+			}
+			// a validator can't have more than one signature in the commit
+			if first, ok := seenVals[string(commitSig.ValidatorAddress)]; ok {
+				return fmt.Errorf("CommitSig #%d duplicates the validator %v of #%d",
+					i, commitSig.ValidatorAddress, first)
+			}
+			seenVals[string(commitSig.ValidatorAddress)] = i
 		}
 	}
 	return nil
@@ -284,6 +284,29 @@ func TestCommit(t *testing.T) {
 	assert.True(t, commit.IsCommit())
 }
 
+func TestCommitSigString(t *testing.T) {
+	var (
+		sig  = []byte("signature-bytes")
+		addr = []byte("validator-address")
+		ts   = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	testCases := []struct {
+		commitSig CommitSig
+		expected  string
+	}{
+		{NewCommitSigAbsent(), "CommitSig{Absent}"},
+		{NewCommitSigForBlock(sig, addr, ts),
+			"CommitSig{7369676E6174 by 76616C696461 on Commit @ 2019-01-01T00:00:00Z}"},
+		{CommitSig{BlockIDFlag: BlockIDFlagNil, ValidatorAddress: addr, Timestamp: ts, Signature: sig},
+			"CommitSig{7369676E6174 by 76616C696461 on Nil @ 2019-01-01T00:00:00Z}"},
+		{CommitSig{BlockIDFlag: BlockIDFlag(9), ValidatorAddress: addr, Timestamp: ts, Signature: sig},
+			"CommitSig{7369676E6174 by 76616C696461 on Unknown(9) @ 2019-01-01T00:00:00Z}"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.commitSig.String())
+	}
+}
+
 func TestCommitValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName       string
@@ -685,6 +708,43 @@ func TestBlockMaxDataBytesNoEvidence(t *testing.T) {
 	}
 }
 
+func TestBlockMaxDataBytesEvidenceBudget(t *testing.T) {
+	maxBytes := DefaultBlockParams().MaxBytes
+	evidenceBytes := DefaultEvidenceParams().MaxBytes
+	for _, valsCount := range []int{1, 4, 100, 10000} {
+		// without an evidence budget, the same as MaxDataBytesNoEvidence
+		assert.Equal(t, MaxDataBytesNoEvidence(maxBytes, valsCount), MaxDataBytes(maxBytes, 0, valsCount),
+			"%d validators", valsCount)
+		// the budget is reserved byte for byte
+		assert.Equal(t, MaxDataBytesNoEvidence(maxBytes, valsCount)-evidenceBytes,
+			MaxDataBytes(maxBytes, evidenceBytes, valsCount), "%d validators", valsCount)
+	}
+}
+
+func TestEstimateMaxDataBytes(t *testing.T) {
+	evidenceBytes := DefaultEvidenceParams().MaxBytes
+	testCases := []struct {
+		maxBytes        int64
+		numValidators   int
+		includeEvidence bool
+		result          int64
+	}{
+		0: {-10, 1, false, 0},
+		1: {849 + int64(vrf.ProofSize), 1, false, 0},
+		2: {851 + int64(vrf.ProofSize), 1, false, 1},
+		3: {962 + int64(vrf.ProofSize), 2, false, 1},
+		4: {962 + int64(vrf.ProofSize), 2, true, 0},
+		5: {962 + int64(vrf.ProofSize) + evidenceBytes, 2, true, 1},
+		6: {DefaultBlockParams().MaxBytes, 100, false, MaxDataBytesNoEvidence(DefaultBlockParams().MaxBytes, 100)},
+		7: {DefaultBlockParams().MaxBytes, 100, true, MaxDataBytes(DefaultBlockParams().MaxBytes, evidenceBytes, 100)},
+	}
+
+	for i, tc := range testCases {
+		assert.Equal(t, tc.result,
+			EstimateMaxDataBytes(tc.maxBytes, tc.numValidators, tc.includeEvidence), "#%v", i)
+	}
+}
+
 func TestCommitToVoteSet(t *testing.T) {
 	lastID := makeBlockIDRandom()
 	h := int64(3)
@@ -715,6 +775,71 @@ func TestCommitToVoteSet(t *testing.T) {
 	}
 }
 
+func TestCommitFromVotes(t *testing.T) {
+	blockID := makeBlockIDRandom()
+	const (
+		height = int64(2)
+		round  = int32(1)
+	)
+
+	voteSet, valSet, vals := randVoteSet(height, round, tmproto.PrecommitType, 10, 1)
+	_, err := MakeCommit(blockID, height, round, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	// a partial list of votes, out of order
+	var votes []*Vote
+	for _, idx := range []int32{7, 0, 1, 6, 9, 3, 4} {
+		votes = append(votes, voteSet.GetByIndex(idx))
+	}
+	nilVote := voteSet.GetByIndex(8).Copy()
+	nilVote.BlockID = BlockID{}
+	pbVote := nilVote.ToProto()
+	require.NoError(t, vals[8].SignVote(voteSet.ChainID(), pbVote))
+	nilVote.Signature = pbVote.Signature
+	votes = append(votes, nilVote)
+
+	commit, err := CommitFromVotes(height, round, blockID, votes, len(vals))
+	require.NoError(t, err)
+	assert.Equal(t, height, commit.Height)
+	assert.Equal(t, round, commit.Round)
+	assert.Equal(t, blockID, commit.BlockID)
+	require.Len(t, commit.Signatures, len(vals))
+	for idx, commitSig := range commit.Signatures {
+		switch idx {
+		case 2, 5:
+			assert.True(t, commitSig.Absent(), idx)
+		case 8:
+			assert.Equal(t, BlockIDFlagNil, commitSig.BlockIDFlag)
+		default:
+			assert.Equal(t, voteSet.GetByIndex(int32(idx)).CommitSig(), commitSig, idx)
+		}
+	}
+	// 7 of 10 signed the block
+	err = valSet.VerifyCommit(voteSet.ChainID(), blockID, height, commit)
+	assert.NoError(t, err)
+
+	vote := voteSet.GetByIndex(0)
+	modified := func(modify func(v *Vote)) []*Vote {
+		v := vote.Copy()
+		modify(v)
+		return []*Vote{v}
+	}
+	testCases := map[string][]*Vote{
+		"nil vote":       {nil},
+		"prevote":        modified(func(v *Vote) { v.Type = tmproto.PrevoteType }),
+		"other height":   modified(func(v *Vote) { v.Height++ }),
+		"other round":    modified(func(v *Vote) { v.Round++ }),
+		"other block":    modified(func(v *Vote) { v.BlockID = makeBlockIDRandom() }),
+		"negative index": modified(func(v *Vote) { v.ValidatorIndex = -1 }),
+		"index too high": modified(func(v *Vote) { v.ValidatorIndex = int32(len(vals)) }),
+		"duplicate vote": {vote, vote},
+	}
+	for name, votes := range testCases {
+		_, err := CommitFromVotes(height, round, blockID, votes, len(vals))
+		assert.Error(t, err, name)
+	}
+}
+
 func TestCommitToVoteSetWithVotesForNilBlock(t *testing.T) {
 	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
 
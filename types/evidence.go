@@ -46,6 +46,21 @@ type Evidence interface {
 	ValidateBasic() error  // basic consistency check
 }
 
+// EvidenceWithinWindow returns true if the evidence has not expired at the
+// given height and time, i.e. unless it's both older than
+// params.Evidence.MaxAgeNumBlocks blocks and params.Evidence.MaxAgeDuration.
+func EvidenceWithinWindow(
+	ev Evidence,
+	params tmproto.ConsensusParams,
+	currentHeight int64,
+	currentTime time.Time,
+) bool {
+	ageNumBlocks := currentHeight - ev.Height()
+	ageDuration := currentTime.Sub(ev.Time())
+	return ageNumBlocks <= params.Evidence.MaxAgeNumBlocks ||
+		ageDuration <= params.Evidence.MaxAgeDuration
+}
+
 //--------------------------------------------------------------------------------------
 
 // DuplicateVoteEvidence contains evidence of a single validator signing two conflicting votes.
@@ -89,6 +104,56 @@ func NewDuplicateVoteEvidence(vote1, vote2 *Vote, blockTime time.Time, valSet *V
 	}
 }
 
+// FindEquivocations returns the evidence of the validators of valSet signing
+// conflicting votes among the given ones: votes of the same height, round and
+// type for different blocks. The nil votes, the votes of the validators out of
+// valSet and the votes whose signature doesn't verify are ignored. A validator
+// voting for more than 2 blocks is reported once per additional block, against
+// its first vote. The votes are expected to be of the height of valSet, whose
+// block time is the time of the evidence.
+func FindEquivocations(
+	chainID string,
+	votes []*Vote,
+	valSet *ValidatorSet,
+	blockTime time.Time,
+) []*DuplicateVoteEvidence {
+	type voteKey struct {
+		address string
+		height  int64
+		round   int32
+		typ     tmproto.SignedMsgType
+	}
+	type voteGroup struct {
+		first  *Vote
+		blocks map[string]bool
+	}
+
+	var evidence []*DuplicateVoteEvidence
+	groups := make(map[voteKey]*voteGroup)
+	for _, vote := range votes {
+		if vote == nil {
+			continue
+		}
+		_, val := valSet.GetByAddress(vote.ValidatorAddress)
+		if val == nil || vote.Verify(chainID, val.PubKey) != nil {
+			continue
+		}
+
+		key := voteKey{string(vote.ValidatorAddress), vote.Height, vote.Round, vote.Type}
+		group, ok := groups[key]
+		if !ok {
+			groups[key] = &voteGroup{first: vote, blocks: map[string]bool{vote.BlockID.Key(): true}}
+			continue
+		}
+		if group.blocks[vote.BlockID.Key()] {
+			continue
+		}
+		group.blocks[vote.BlockID.Key()] = true
+		evidence = append(evidence, NewDuplicateVoteEvidence(group.first, vote, blockTime, valSet))
+	}
+	return evidence
+}
+
 // ABCI returns the application relevant representation of the evidence
 func (dve *DuplicateVoteEvidence) ABCI() []abci.Evidence {
 	return []abci.Evidence{{
@@ -565,6 +630,36 @@ func (err *ErrEvidenceOverflow) Error() string {
 	return fmt.Sprintf("Too much evidence: Max %d, got %d", err.Max, err.Got)
 }
 
+// ErrTooManyEvidence is for when there the number of evidence exceeds the max
+// count (see OCConsensusParams.MaxEvidencePerBlock).
+type ErrTooManyEvidence struct {
+	Max int64
+	Got int64
+}
+
+// Error returns a string representation of the error.
+func (err *ErrTooManyEvidence) Error() string {
+	return fmt.Sprintf("Too many evidence: Max %d, got %d", err.Max, err.Got)
+}
+
+// ValidateEvidenceLimit returns an error if the evidence of a block exceeds
+// the MaxEvidencePerBlock of the ostracon params (ErrTooManyEvidence) or the
+// Evidence.MaxBytes of the consensus params (ErrEvidenceOverflow).
+func ValidateEvidenceLimit(
+	evidence []Evidence,
+	params tmproto.ConsensusParams,
+	ocParams OCConsensusParams,
+) error {
+	if max, got := ocParams.MaxEvidencePerBlock, int64(len(evidence)); max > 0 && got > max {
+		return &ErrTooManyEvidence{max, got}
+	}
+	data := EvidenceData{Evidence: evidence}
+	if max, got := params.Evidence.MaxBytes, data.ByteSize(); got > max {
+		return NewErrEvidenceOverflow(max, got)
+	}
+	return nil
+}
+
 //-------------------------------------------- MOCKING --------------------------------------
 
 // unstable - use only for testing
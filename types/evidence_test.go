@@ -79,6 +79,104 @@ func TestDuplicateVoteEvidence(t *testing.T) {
 	assert.NotNil(t, ev.String())
 }
 
+func TestFindEquivocations(t *testing.T) {
+	const chainID = "mychain"
+	valSet, privVals := RandValidatorSet(4, 10)
+	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
+	blockID2 := makeBlockID([]byte("blockhash2"), 1000, []byte("partshash"))
+	vote := func(valIdx int, round int32, step int, blockID BlockID) *Vote {
+		return makeVote(t, privVals[valIdx], chainID, int32(valIdx), 10, round, step, blockID, defaultVoteTime)
+	}
+
+	var votes []*Vote
+	for i := range privVals {
+		votes = append(votes, vote(i, 0, 1, blockID))
+	}
+	forged := vote(2, 0, 1, blockID2)
+	forged.Signature = forged.Signature[1:]
+	votes = append(votes,
+		nil,
+		// the validator 0 equivocates
+		vote(0, 0, 1, blockID2),
+		// not conflicting: another type, another round or the same block
+		vote(0, 0, 2, blockID2),
+		vote(1, 1, 1, blockID2),
+		vote(1, 0, 1, blockID),
+		// not signed by the validator 2
+		forged,
+	)
+
+	evidence := FindEquivocations(chainID, votes, valSet, defaultVoteTime)
+	require.Len(t, evidence, 1)
+	ev := evidence[0]
+	assert.NoError(t, ev.ValidateBasic())
+	assert.Equal(t, NewDuplicateVoteEvidence(votes[0], votes[5], defaultVoteTime, valSet), ev)
+	assert.Equal(t, valSet.Validators[0].Address, ev.VoteA.ValidatorAddress)
+	assert.EqualValues(t, 40, ev.TotalVotingPower)
+	assert.EqualValues(t, 10, ev.ValidatorPower)
+
+	assert.Empty(t, FindEquivocations("otherchain", votes, valSet, defaultVoteTime))
+}
+
+func TestValidateEvidenceLimit(t *testing.T) {
+	evidence := []Evidence{
+		randomDuplicatedVoteEvidence(t),
+		randomDuplicatedVoteEvidence(t),
+		randomDuplicatedVoteEvidence(t),
+	}
+	data := EvidenceData{Evidence: evidence}
+	size := data.ByteSize()
+	params := DefaultConsensusParams()
+	ocParams := DefaultOCConsensusParams()
+
+	// the bytes
+	params.Evidence.MaxBytes = size
+	assert.NoError(t, ValidateEvidenceLimit(evidence, *params, *ocParams))
+	params.Evidence.MaxBytes = size - 1
+	err := ValidateEvidenceLimit(evidence, *params, *ocParams)
+	assert.Equal(t, NewErrEvidenceOverflow(size-1, size), err)
+
+	// the count, no maximum by default
+	params.Evidence.MaxBytes = size
+	ocParams.MaxEvidencePerBlock = 3
+	assert.NoError(t, ValidateEvidenceLimit(evidence, *params, *ocParams))
+	ocParams.MaxEvidencePerBlock = 2
+	err = ValidateEvidenceLimit(evidence, *params, *ocParams)
+	assert.Equal(t, &ErrTooManyEvidence{2, 3}, err)
+	assert.NoError(t, ValidateEvidenceLimit(evidence[:2], *params, *ocParams))
+
+	assert.NoError(t, ValidateEvidenceLimit(nil, *params, *ocParams))
+}
+
+func TestEvidenceWithinWindow(t *testing.T) {
+	const height = int64(10)
+	evTime := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	ev := NewMockDuplicateVoteEvidence(height, evTime, "mock-chain-id")
+	params := DefaultConsensusParams()
+	params.Evidence.MaxAgeNumBlocks = 100
+	params.Evidence.MaxAgeDuration = time.Hour
+
+	var (
+		maxHeight = height + params.Evidence.MaxAgeNumBlocks
+		maxTime   = evTime.Add(params.Evidence.MaxAgeDuration)
+	)
+	testCases := []struct {
+		currentHeight int64
+		currentTime   time.Time
+		within        bool
+	}{
+		{maxHeight, maxTime, true},
+		{maxHeight + 1, maxTime, true},
+		{maxHeight, maxTime.Add(time.Nanosecond), true},
+		{maxHeight + 1, maxTime.Add(time.Nanosecond), false},
+		{height, evTime, true},
+	}
+	for i, tc := range testCases {
+		assert.Equal(t, tc.within,
+			EvidenceWithinWindow(ev, *params, tc.currentHeight, tc.currentTime), "#%d", i)
+	}
+}
+
 func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 	val := NewMockPV()
 	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt32, tmhash.Sum([]byte("partshash")))
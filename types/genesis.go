@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -36,6 +37,19 @@ type GenesisValidator struct {
 	Name    string        `json:"name"`
 }
 
+func (v GenesisValidator) validateBasic() error {
+	if v.Power == 0 {
+		return fmt.Errorf("the genesis file cannot contain validators with no voting power: %v", v)
+	}
+	if v.PubKey == nil {
+		return fmt.Errorf("the genesis file cannot contain validators with no public key: %v", v)
+	}
+	if len(v.Address) > 0 && !bytes.Equal(v.PubKey.Address(), v.Address) {
+		return fmt.Errorf("incorrect address for validator %v in the genesis file, should be %v", v, v.PubKey.Address())
+	}
+	return nil
+}
+
 // GenesisDoc defines the initial conditions for an ostracon blockchain, in particular its validator set.
 type GenesisDoc struct {
 	GenesisTime     time.Time                `json:"genesis_time"`
@@ -45,6 +59,9 @@ type GenesisDoc struct {
 	Validators      []GenesisValidator       `json:"validators,omitempty"`
 	AppHash         tmbytes.HexBytes         `json:"app_hash"`
 	AppState        json.RawMessage          `json:"app_state,omitempty"`
+
+	// *** Ostracon Extended Fields ***
+	OCConsensusParams *OCConsensusParams `json:"oc_consensus_params,omitempty"`
 }
 
 // SaveAs is a utility method for saving GenensisDoc as a JSON file.
@@ -88,17 +105,29 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		return err
 	}
 
-	for i, v := range genDoc.Validators {
-		if v.Power == 0 {
-			return fmt.Errorf("the genesis file cannot contain validators with no voting power: %v", v)
+	// The defaults of the ostracon params aren't filled in, so that the hash
+	// of the genesis docs without them is unchanged.
+	if genDoc.OCConsensusParams != nil {
+		if err := ValidateOCConsensusParams(*genDoc.OCConsensusParams); err != nil {
+			return err
 		}
-		if len(v.Address) > 0 && !bytes.Equal(v.PubKey.Address(), v.Address) {
-			return fmt.Errorf("incorrect address for validator %v in the genesis file, should be %v", v, v.PubKey.Address())
+		if maxVals := genDoc.OCConsensusParams.MaxValidators; maxVals > 0 && int64(len(genDoc.Validators)) > maxVals {
+			return fmt.Errorf("the genesis file has %d validators, more than max_validators %d",
+				len(genDoc.Validators), maxVals)
+		}
+	}
+
+	for i, v := range genDoc.Validators {
+		if err := v.validateBasic(); err != nil {
+			return err
 		}
 		if len(v.Address) == 0 {
 			genDoc.Validators[i].Address = v.PubKey.Address()
 		}
 	}
+	if err := ValidateGenesisValidators(genDoc); err != nil {
+		return err
+	}
 
 	if genDoc.GenesisTime.IsZero() {
 		genDoc.GenesisTime = tmtime.Now()
@@ -107,6 +136,37 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 	return nil
 }
 
+// ValidateGenesisValidators returns an error pointing at the first validator of
+// the genesis doc that would make NewValidatorSet panic at the start of the
+// node: a negative voting power, a duplicate address, or a total voting power
+// exceeding MaxTotalVotingPower.
+func ValidateGenesisValidators(gen *GenesisDoc) error {
+	var (
+		total int64
+		seen  = make(map[string]int, len(gen.Validators))
+	)
+	for i, v := range gen.Validators {
+		address := v.Address
+		if len(address) == 0 && v.PubKey != nil {
+			address = v.PubKey.Address()
+		}
+		if v.Power < 0 {
+			return fmt.Errorf("genesis validator #%d (%v) has negative voting power %d", i, address, v.Power)
+		}
+		if j, ok := seen[string(address)]; ok {
+			return fmt.Errorf("genesis validator #%d has the same address %v as validator #%d", i, address, j)
+		}
+		seen[string(address)] = i
+
+		total = safeAddClip(total, v.Power)
+		if total > MaxTotalVotingPower {
+			return fmt.Errorf("the total voting power exceeds the max %d at genesis validator #%d (%v)",
+				MaxTotalVotingPower, i, address)
+		}
+	}
+	return nil
+}
+
 // Hash returns the hash of the GenesisDoc
 func (genDoc *GenesisDoc) Hash() []byte {
 	genDocBytes, err := tmjson.Marshal(genDoc)
@@ -134,6 +194,103 @@ func GenesisDocFromJSON(jsonBlob []byte) (*GenesisDoc, error) {
 	return &genDoc, err
 }
 
+// GenesisDocFromReader decodes the JSON data of the reader into a GenesisDoc,
+// like GenesisDocFromJSON does. Unlike it, the whole JSON data is never held in
+// memory: the validators are decoded and validated one by one as they are
+// read, so only their decoded form is kept.
+func GenesisDocFromReader(r io.Reader) (*GenesisDoc, error) {
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	// all the fields but the validators are decoded at the end, at once
+	fields := make(map[string]json.RawMessage)
+	var validators []GenesisValidator
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a field name, got %v", tok)
+		}
+		if key != "validators" {
+			var field json.RawMessage
+			if err := dec.Decode(&field); err != nil {
+				return nil, fmt.Errorf("error decoding %s: %w", key, err)
+			}
+			fields[key] = field
+			continue
+		}
+		if validators, err = decodeGenesisValidators(dec); err != nil {
+			return nil, err
+		}
+	}
+	if err := expectJSONDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	bz, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	genDoc := GenesisDoc{}
+	if err := tmjson.Unmarshal(bz, &genDoc); err != nil {
+		return nil, err
+	}
+	genDoc.Validators = validators
+
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+	return &genDoc, nil
+}
+
+// decodeGenesisValidators decodes and validates the validators of a genesis
+// doc one by one. The validators may be null.
+func decodeGenesisValidators(dec *json.Decoder) ([]GenesisValidator, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("expected validators to be an array, got %v", tok)
+	}
+
+	validators := []GenesisValidator{}
+	for i := 0; dec.More(); i++ {
+		var bz json.RawMessage
+		if err := dec.Decode(&bz); err != nil {
+			return nil, fmt.Errorf("error decoding validator #%d: %w", i, err)
+		}
+		var v GenesisValidator
+		if err := tmjson.Unmarshal(bz, &v); err != nil {
+			return nil, fmt.Errorf("error decoding validator #%d: %w", i, err)
+		}
+		if err := v.validateBasic(); err != nil {
+			return nil, err
+		}
+		validators = append(validators, v)
+	}
+	return validators, expectJSONDelim(dec, ']')
+}
+
+func expectJSONDelim(dec *json.Decoder, expected json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("expected %v, got %v", expected, tok)
+	}
+	return nil
+}
+
 // GenesisDocFromFile reads JSON data from a file and unmarshalls it into a GenesisDoc.
 func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
 	jsonBlob, err := os.ReadFile(genDocFile)
@@ -1,8 +1,12 @@
 package types
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,6 +58,8 @@ func TestGenesisBad(t *testing.T) {
 	for _, testCase := range testCases {
 		_, err := GenesisDocFromJSON(testCase)
 		assert.Error(t, err, "expected error for empty genDoc json")
+		_, err = GenesisDocFromReader(bytes.NewReader(testCase))
+		assert.Error(t, err, "expected error for empty genDoc json")
 	}
 }
 
@@ -118,7 +124,103 @@ func TestGenesisGood(t *testing.T) {
 	for _, tc := range missingValidatorsTestCases {
 		_, err := GenesisDocFromJSON(tc)
 		assert.NoError(t, err)
+		_, err = GenesisDocFromReader(bytes.NewReader(tc))
+		assert.NoError(t, err)
+	}
+}
+
+func TestGenesisDocFromReader(t *testing.T) {
+	pubkey := ed25519.GenPrivKey().PubKey()
+	genDoc := &GenesisDoc{
+		GenesisTime: tmtime.Now(),
+		ChainID:     "abc",
+		Validators:  []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+		AppState:    []byte(`{"account_owner":"Bob"}`),
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	genDocBytes, err := tmjson.Marshal(genDoc)
+	require.NoError(t, err)
+
+	expected, err := GenesisDocFromJSON(genDocBytes)
+	require.NoError(t, err)
+	actual, err := GenesisDocFromReader(bytes.NewReader(genDocBytes))
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, genDoc.Hash(), actual.Hash())
+}
+
+func TestGenesisDocFromReaderLarge(t *testing.T) {
+	const numValidators = 10000
+	pubKeys := make([]string, numValidators)
+	for i := range pubKeys {
+		bz, err := tmjson.Marshal(ed25519.GenPrivKey().PubKey())
+		require.NoError(t, err)
+		pubKeys[i] = string(bz)
+	}
+
+	// the genesis is generated as it is read, so it's never held in memory
+	r, w := io.Pipe()
+	go func() {
+		_, err := io.WriteString(w, `{"chain_id":"large-chain","validators":[`)
+		for i := 0; i < numValidators && err == nil; i++ {
+			if i > 0 {
+				_, err = io.WriteString(w, ",")
+			}
+			if err == nil {
+				_, err = fmt.Fprintf(w, `{"pub_key":%s,"power":"%d","name":"val%d"}`,
+					pubKeys[i], i+1, i)
+			}
+		}
+		if err == nil {
+			_, err = io.WriteString(w, `],"app_state":{}}`)
+		}
+		w.CloseWithError(err)
+	}()
+
+	genDoc, err := GenesisDocFromReader(r)
+	require.NoError(t, err)
+	assert.Equal(t, "large-chain", genDoc.ChainID)
+	require.Len(t, genDoc.Validators, numValidators)
+	for i, v := range genDoc.Validators {
+		assert.EqualValues(t, i+1, v.Power)
+		assert.Equal(t, fmt.Sprintf("val%d", i), v.Name)
+		assert.Equal(t, v.PubKey.Address(), v.Address)
 	}
+	// the validators have distinct keys, so they make a valid validator set
+	assert.NotPanics(t, func() { genDoc.ValidatorHash() })
+
+	// an invalid validator stops the decoding
+	_, err = GenesisDocFromReader(strings.NewReader(
+		`{"chain_id":"c","validators":[{"pub_key":` + pubKeys[0] + `,"power":"0"}],"app_state":{}}`))
+	assert.Error(t, err)
+}
+
+func TestGenesisOCConsensusParams(t *testing.T) {
+	// the defaults aren't filled in, so the hash of the doc is unchanged
+	genDoc, err := GenesisDocFromJSON([]byte(`{"chain_id":"mychain"}`))
+	require.NoError(t, err)
+	assert.Nil(t, genDoc.OCConsensusParams)
+	genDocBytes, err := tmjson.Marshal(genDoc)
+	require.NoError(t, err)
+	assert.NotContains(t, string(genDocBytes), "oc_consensus_params")
+
+	genDoc, err = GenesisDocFromJSON(
+		[]byte(`{"chain_id":"mychain","oc_consensus_params":{"vrf_message_domain":"domain"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, &OCConsensusParams{VRFMessageDomain: "domain"}, genDoc.OCConsensusParams)
+
+	tooLong := strings.Repeat("a", MaxVRFMessageDomainLen+1)
+	_, err = GenesisDocFromJSON(
+		[]byte(`{"chain_id":"mychain","oc_consensus_params":{"vrf_message_domain":"` + tooLong + `"}}`))
+	assert.Error(t, err)
+
+	// the genesis validators can't exceed max_validators
+	genDoc = randomGenesisDoc()
+	genDoc.OCConsensusParams = &OCConsensusParams{MaxValidators: 1}
+	assert.NoError(t, genDoc.ValidateAndComplete())
+	pubkey := ed25519.GenPrivKey().PubKey()
+	genDoc.Validators = append(genDoc.Validators, GenesisValidator{pubkey.Address(), pubkey, 10, "myval2"})
+	assert.Error(t, genDoc.ValidateAndComplete())
 }
 
 func TestGenesisSaveAs(t *testing.T) {
@@ -152,6 +254,35 @@ func TestGenesisValidatorHash(t *testing.T) {
 	assert.NotEmpty(t, genDoc.ValidatorHash())
 }
 
+func TestValidateGenesisValidators(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	require.NoError(t, ValidateGenesisValidators(genDoc))
+
+	// overflowing total voting power
+	pubkey := ed25519.GenPrivKey().PubKey()
+	genDoc.Validators = append(genDoc.Validators,
+		GenesisValidator{pubkey.Address(), pubkey, MaxTotalVotingPower, "big"})
+	err := ValidateGenesisValidators(genDoc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "#1")
+	assert.Error(t, genDoc.ValidateAndComplete())
+
+	// duplicate address, left for ValidateAndComplete to complete
+	genDoc = randomGenesisDoc()
+	genDoc.Validators = append(genDoc.Validators,
+		GenesisValidator{nil, genDoc.Validators[0].PubKey, 20, "dup"})
+	err = ValidateGenesisValidators(genDoc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "same address")
+	assert.Contains(t, err.Error(), "#1")
+	assert.Error(t, genDoc.ValidateAndComplete())
+
+	// negative voting power
+	genDoc = randomGenesisDoc()
+	genDoc.Validators[0].Power = -1
+	assert.Error(t, ValidateGenesisValidators(genDoc))
+}
+
 func randomGenesisDoc() *GenesisDoc {
 	pubkey := ed25519.GenPrivKey().PubKey()
 	return &GenesisDoc{
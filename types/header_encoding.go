@@ -0,0 +1,90 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	amino "github.com/tendermint/go-amino"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// VerifyHeaderEncodingEquivalence checks that the given header decodes to the
+// same header from both its amino and proto encodings. The encodings differ
+// (amino encodes int64 as zig-zag varints), but the decoded headers must be
+// semantically equal, e.g. when migrating stored blocks from amino to proto.
+func VerifyHeaderEncodingEquivalence(h *Header) error {
+	if h == nil {
+		return errors.New("nil header")
+	}
+
+	cdc := amino.NewCodec()
+	aminoBz, err := cdc.MarshalBinaryBare(h)
+	if err != nil {
+		return fmt.Errorf("amino encoding: %w", err)
+	}
+	var fromAmino Header
+	if err := cdc.UnmarshalBinaryBare(aminoBz, &fromAmino); err != nil {
+		return fmt.Errorf("amino decoding: %w", err)
+	}
+
+	pbh := h.ToProto()
+	protoBz, err := proto.Marshal(pbh)
+	if err != nil {
+		return fmt.Errorf("proto encoding: %w", err)
+	}
+	var pbFromProto tmproto.Header
+	if err := proto.Unmarshal(protoBz, &pbFromProto); err != nil {
+		return fmt.Errorf("proto decoding: %w", err)
+	}
+	fromProto, err := HeaderFromProto(&pbFromProto)
+	if err != nil {
+		return fmt.Errorf("proto decoding: %w", err)
+	}
+
+	if field := headerMismatch(&fromAmino, &fromProto); field != "" {
+		return fmt.Errorf("amino and proto decoded headers differ in %s", field)
+	}
+	if field := headerMismatch(h, &fromProto); field != "" {
+		return fmt.Errorf("proto decoded header differs from the original in %s", field)
+	}
+	return nil
+}
+
+// headerMismatch returns the name of the first field the headers differ in,
+// or an empty string if they are equal. Nil and empty byte slices are equal.
+func headerMismatch(a, b *Header) string {
+	switch {
+	case a.Version != b.Version:
+		return "Version"
+	case a.ChainID != b.ChainID:
+		return "ChainID"
+	case a.Height != b.Height:
+		return "Height"
+	case !a.Time.Equal(b.Time):
+		return "Time"
+	case !a.LastBlockID.Equals(b.LastBlockID):
+		return "LastBlockID"
+	case !bytes.Equal(a.LastCommitHash, b.LastCommitHash):
+		return "LastCommitHash"
+	case !bytes.Equal(a.DataHash, b.DataHash):
+		return "DataHash"
+	case !bytes.Equal(a.ValidatorsHash, b.ValidatorsHash):
+		return "ValidatorsHash"
+	case !bytes.Equal(a.NextValidatorsHash, b.NextValidatorsHash):
+		return "NextValidatorsHash"
+	case !bytes.Equal(a.ConsensusHash, b.ConsensusHash):
+		return "ConsensusHash"
+	case !bytes.Equal(a.AppHash, b.AppHash):
+		return "AppHash"
+	case !bytes.Equal(a.LastResultsHash, b.LastResultsHash):
+		return "LastResultsHash"
+	case !bytes.Equal(a.EvidenceHash, b.EvidenceHash):
+		return "EvidenceHash"
+	case !bytes.Equal(a.ProposerAddress, b.ProposerAddress):
+		return "ProposerAddress"
+	}
+	return ""
+}
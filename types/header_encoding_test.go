@@ -0,0 +1,47 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/line/ostracon/crypto"
+	"github.com/line/ostracon/crypto/tmhash"
+	"github.com/line/ostracon/version"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+)
+
+func TestVerifyHeaderEncodingEquivalence(t *testing.T) {
+	header := &Header{
+		Version:            tmversion.Consensus{Block: version.BlockProtocol, App: 8},
+		ChainID:            "chainID",
+		Height:             5,
+		Time:               time.Now().UTC(),
+		LastBlockID:        makeBlockID(tmhash.Sum([]byte("hash")), 10, tmhash.Sum([]byte("parts"))),
+		LastCommitHash:     tmhash.Sum([]byte("lastCommitHash")),
+		DataHash:           tmhash.Sum([]byte("dataHash")),
+		ValidatorsHash:     tmhash.Sum([]byte("valHash")),
+		NextValidatorsHash: tmhash.Sum([]byte("nextValHash")),
+		ConsensusHash:      tmhash.Sum([]byte("consHash")),
+		AppHash:            tmhash.Sum([]byte("appHash")),
+		LastResultsHash:    tmhash.Sum([]byte("lastResultsHash")),
+		EvidenceHash:       tmhash.Sum([]byte("evidenceHash")),
+		ProposerAddress:    crypto.AddressHash([]byte("proposerAddress")),
+	}
+	require.NoError(t, VerifyHeaderEncodingEquivalence(header))
+
+	// the zig-zag and plain varints of a large height differ in length
+	header.Height = 1 << 40
+	require.NoError(t, VerifyHeaderEncodingEquivalence(header))
+
+	// invalid headers are rejected by the proto decoding
+	assert.Error(t, VerifyHeaderEncodingEquivalence(&Header{}))
+	assert.Error(t, VerifyHeaderEncodingEquivalence(nil))
+
+	other := *header
+	other.AppHash = tmhash.Sum([]byte("otherAppHash"))
+	assert.Equal(t, "AppHash", headerMismatch(header, &other))
+	assert.Equal(t, "", headerMismatch(header, header))
+}
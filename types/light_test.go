@@ -31,6 +31,18 @@ func TestLightBlockValidateBasic(t *testing.T) {
 		Commit: commit,
 	}
 
+	// the commit signs another block
+	commit2 := *commit
+	commit2.BlockID = makeBlockIDRandom()
+	// the commit is for another height
+	commit3 := *commit
+	commit3.Height++
+	// the header belongs to another chain
+	header2 := header
+	header2.ChainID = "other_chain_id"
+	commit4 := *commit
+	commit4.BlockID.Hash = header2.Hash()
+
 	testCases := []struct {
 		name      string
 		sh        *SignedHeader
@@ -41,6 +53,9 @@ func TestLightBlockValidateBasic(t *testing.T) {
 		{"hashes don't match", sh, vals2, true},
 		{"invalid validator set", sh, vals3, true},
 		{"invalid signed header", &SignedHeader{Header: &header, Commit: randCommit(time.Now())}, vals, true},
+		{"commit doesn't match header hash", &SignedHeader{Header: &header, Commit: &commit2}, vals, true},
+		{"commit doesn't match header height", &SignedHeader{Header: &header, Commit: &commit3}, vals, true},
+		{"header of another chain", &SignedHeader{Header: &header2, Commit: &commit4}, vals, true},
 		{"empty signed header", nil, vals, true},
 		{"empty validator set", sh, nil, true},
 	}
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxVRFMessageDomainLen is the maximum length of the VRF message domain.
+	MaxVRFMessageDomainLen = 64
+)
+
+// OCConsensusParams are the ostracon specific consensus params.
+//
+// The upstream tendermint ConsensusParams can't be extended, so these params
+// are set in the genesis file, persisted with the state, and can't be changed
+// by the app afterwards. All the nodes of a network must use the same params:
+// they change the elected proposers and the validity of the blocks.
+//
+// The zero value of every field is its default.
+type OCConsensusParams struct {
+	// VRFMessageDomain is the domain-separation tag mixed into the messages
+	// the proposers prove with their VRF, so that a proof can't be reused in
+	// another context. Empty, the messages are not tagged.
+	VRFMessageDomain string `json:"vrf_message_domain,omitempty"`
+	// VRFSeedMixing selects how the seeds of the VRF messages are built: the
+	// legacy round hash (MakeRoundHash) if false, or a tagged hash of the
+	// previous VRF output, the height and the round if true.
+	VRFSeedMixing bool `json:"vrf_seed_mixing,omitempty"`
+	// ProposerSelectionPrecision is the number of random bits the proposer is
+	// sampled with, a multiple of 63 up to MaxProposerSelectionPrecision. The
+	// sample is a point of the total voting power, rounded down: the more bits,
+	// the less the selection is biased by the rounding, which matters for the
+	// validators with a tiny share of a huge total voting power. 0 means
+	// DefaultProposerSelectionPrecision.
+	ProposerSelectionPrecision uint32 `json:"proposer_selection_precision,omitempty"`
+	// MaxEvidencePerBlock is the maximum number of evidence a block can carry,
+	// on top of the Evidence.MaxBytes consensus param. 0 means no maximum.
+	MaxEvidencePerBlock int64 `json:"max_evidence_per_block,omitempty"`
+	// ProposerTieBreak is the order in which the validators of the same voting
+	// power are sampled by the proposer selection.
+	ProposerTieBreak ProposerTieBreak `json:"proposer_tie_break,omitempty"`
+	// MaxValidators is the maximum number of validators of the set: the
+	// validator updates of the app which would exceed it are rejected. 0 means
+	// no maximum.
+	MaxValidators int64 `json:"max_validators,omitempty"`
+	// CommitTimestampTolerance is the maximum deviation of the timestamps of
+	// the signatures of the last commit of a block from the time of the last
+	// block: the blocks whose last commit deviates more are invalid. 0 means
+	// no maximum.
+	CommitTimestampTolerance time.Duration `json:"commit_timestamp_tolerance,omitempty"`
+}
+
+// DefaultOCConsensusParams returns a default OCConsensusParams.
+func DefaultOCConsensusParams() *OCConsensusParams {
+	return &OCConsensusParams{}
+}
+
+// ValidateOCConsensusParams validates the OCConsensusParams to ensure all
+// values are within their allowed limits, and returns an error if they are not.
+func ValidateOCConsensusParams(params OCConsensusParams) error {
+	if len(params.VRFMessageDomain) > MaxVRFMessageDomainLen {
+		return fmt.Errorf("vrf_message_domain is too long (max: %d)", MaxVRFMessageDomainLen)
+	}
+
+	if bits := params.ProposerSelectionPrecision; bits%63 != 0 || bits > MaxProposerSelectionPrecision {
+		return fmt.Errorf("proposer_selection_precision must be a multiple of 63 up to %d, got %d",
+			MaxProposerSelectionPrecision, bits)
+	}
+
+	if params.MaxEvidencePerBlock < 0 {
+		return fmt.Errorf("max_evidence_per_block must be non negative. Got: %d",
+			params.MaxEvidencePerBlock)
+	}
+
+	switch params.ProposerTieBreak {
+	case ProposerTieBreakByAddress, ProposerTieBreakByPubKeyHash:
+	default:
+		return fmt.Errorf("unknown proposer_tie_break %d", params.ProposerTieBreak)
+	}
+
+	if params.MaxValidators < 0 {
+		return fmt.Errorf("max_validators must be non negative. Got: %d",
+			params.MaxValidators)
+	}
+
+	if params.CommitTimestampTolerance < 0 {
+		return fmt.Errorf("commit_timestamp_tolerance must be non negative. Got: %v",
+			params.CommitTimestampTolerance)
+	}
+
+	return nil
+}
+
+// proposerSelectionPrecision returns the number of random bits the proposer is
+// sampled with.
+func (params OCConsensusParams) proposerSelectionPrecision() uint {
+	if params.ProposerSelectionPrecision == 0 {
+		return DefaultProposerSelectionPrecision
+	}
+	return uint(params.ProposerSelectionPrecision)
+}
@@ -9,6 +9,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	"github.com/line/ostracon/crypto/tmhash"
+	tmjson "github.com/line/ostracon/libs/json"
 	"github.com/line/ostracon/version"
 )
 
@@ -185,3 +186,26 @@ func UpdateConsensusParams(params tmproto.ConsensusParams, params2 *abci.Consens
 	}
 	return res
 }
+
+// ConsensusParamsToJSON validates the params and encodes them to JSON, in the
+// same format as the consensus_params of the genesis file.
+func ConsensusParamsToJSON(params tmproto.ConsensusParams) ([]byte, error) {
+	if err := ValidateConsensusParams(params); err != nil {
+		return nil, fmt.Errorf("invalid consensus params: %w", err)
+	}
+	return tmjson.MarshalIndent(params, "", "  ")
+}
+
+// ConsensusParamsFromJSON decodes params encoded with ConsensusParamsToJSON
+// and validates them. All the params must be set: missing fields aren't filled
+// with their defaults.
+func ConsensusParamsFromJSON(bz []byte) (tmproto.ConsensusParams, error) {
+	var params tmproto.ConsensusParams
+	if err := tmjson.Unmarshal(bz, &params); err != nil {
+		return tmproto.ConsensusParams{}, fmt.Errorf("decoding consensus params: %w", err)
+	}
+	if err := ValidateConsensusParams(params); err != nil {
+		return tmproto.ConsensusParams{}, fmt.Errorf("invalid consensus params: %w", err)
+	}
+	return params, nil
+}
@@ -2,7 +2,9 @@ package types
 
 import (
 	"bytes"
+	"fmt"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -102,6 +105,17 @@ func TestConsensusParamsHash(t *testing.T) {
 	}
 }
 
+func TestConsensusParamsHashGolden(t *testing.T) {
+	// a change of this hash breaks the headers of the existing chains
+	params := DefaultConsensusParams()
+	assert.Equal(t, "048091BC7DDC283F77BFBF91D73C44DA58C3DF8A9CBC867405D8B7F3DAADA22F", fmt.Sprintf("%X", HashConsensusParams(*params)))
+
+	// only the block params are hashed
+	params.Evidence.MaxAgeNumBlocks++
+	params.Validator.PubKeyTypes = []string{ABCIPubKeyTypeSecp256k1}
+	assert.Equal(t, HashConsensusParams(*DefaultConsensusParams()), HashConsensusParams(*params))
+}
+
 func TestConsensusParamsUpdate(t *testing.T) {
 	testCases := []struct {
 		params        tmproto.ConsensusParams
@@ -149,3 +163,57 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 
 	assert.EqualValues(t, 77, updated.Version.AppVersion)
 }
+
+const defaultConsensusParamsJSON = `{
+  "block": {
+    "max_bytes": "22020096",
+    "max_gas": "-1",
+    "time_iota_ms": "1000"
+  },
+  "evidence": {
+    "max_age_num_blocks": "100000",
+    "max_age_duration": "172800000000000",
+    "max_bytes": "1048576"
+  },
+  "validator": {
+    "pub_key_types": [
+      "ed25519"
+    ]
+  },
+  "version": {}
+}`
+
+func TestConsensusParamsJSON(t *testing.T) {
+	bz, err := ConsensusParamsToJSON(*DefaultConsensusParams())
+	require.NoError(t, err)
+	assert.Equal(t, defaultConsensusParamsJSON, string(bz))
+
+	params, err := ConsensusParamsFromJSON(bz)
+	require.NoError(t, err)
+	assert.Equal(t, *DefaultConsensusParams(), params)
+
+	// invalid params are not encoded
+	invalid := *DefaultConsensusParams()
+	invalid.Block.MaxBytes = -1
+	_, err = ConsensusParamsToJSON(invalid)
+	assert.Error(t, err)
+}
+
+func TestConsensusParamsFromJSONInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"malformed":             `{"block": {`,
+		"wrong type":            `{"block": {"max_bytes": true}}`,
+		"empty":                 `{}`,
+		"negative max bytes":    `{"block": {"max_bytes": "-1", "max_gas": "-1", "time_iota_ms": "1000"}}`,
+		"missing evidence":      `{"block": {"max_bytes": "1024", "max_gas": "-1", "time_iota_ms": "1000"}}`,
+		"negative evidence":     strings.Replace(defaultConsensusParamsJSON, `"1048576"`, `"-1"`, 1),
+		"unknown pub key type":  strings.Replace(defaultConsensusParamsJSON, `"ed25519"`, `"rsa"`, 1),
+		"missing pub key types": strings.Replace(defaultConsensusParamsJSON, `"ed25519"`, ``, 1),
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ConsensusParamsFromJSON([]byte(tc))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 var (
 	ErrPartSetUnexpectedIndex = errors.New("error part set unexpected index")
 	ErrPartSetInvalidProof    = errors.New("error part set invalid proof")
+	ErrPartSetMismatchedProof = errors.New("error part set proof does not match part position")
 )
 
 type Part struct {
@@ -281,6 +282,13 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 		return false, nil
 	}
 
+	// Check that the proof is for this part's position in the set. Verify
+	// only checks the proof against the root, so a part carrying a valid proof
+	// for another leaf would otherwise be stored at the wrong index.
+	if part.Proof.Total != int64(ps.total) || part.Proof.Index != int64(part.Index) {
+		return false, ErrPartSetMismatchedProof
+	}
+
 	// Check hash proof
 	if part.Proof.Verify(ps.Hash(), part.Bytes) != nil {
 		return false, ErrPartSetInvalidProof
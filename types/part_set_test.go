@@ -88,6 +88,33 @@ func TestWrongProof(t *testing.T) {
 	}
 }
 
+func TestMismatchedPartProof(t *testing.T) {
+	data := tmrand.Bytes(testPartSize * 10)
+	partSet := NewPartSetFromData(data, testPartSize)
+	partSet2 := NewPartSetFromHeader(partSet.Header())
+
+	// A part carrying a valid proof for another leaf is rejected.
+	part := partSet.GetPart(1)
+	added, err := partSet2.AddPart(&Part{Index: 0, Bytes: part.Bytes, Proof: part.Proof})
+	assert.False(t, added)
+	assert.Equal(t, ErrPartSetMismatchedProof, err)
+	assert.False(t, partSet2.IsComplete())
+	assert.EqualValues(t, 0, partSet2.Count())
+
+	// So is a part whose proof was built for a set of a different size.
+	proof := part.Proof
+	proof.Total++
+	added, err = partSet2.AddPart(&Part{Index: 1, Bytes: part.Bytes, Proof: proof})
+	assert.False(t, added)
+	assert.Equal(t, ErrPartSetMismatchedProof, err)
+
+	// The genuine part is still accepted afterwards.
+	added, err = partSet2.AddPart(part)
+	assert.True(t, added)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, partSet2.Count())
+}
+
 func TestPartSetHeaderValidateBasic(t *testing.T) {
 	testCases := []struct {
 		testName              string
@@ -9,6 +9,7 @@ import (
 
 	"github.com/line/ostracon/crypto"
 	"github.com/line/ostracon/crypto/ed25519"
+	"github.com/line/ostracon/crypto/secp256k1"
 )
 
 // PrivValidator defines the functionality of a local Ostracon validator
@@ -22,6 +23,42 @@ type PrivValidator interface {
 	GenerateVRFProof(message []byte) (crypto.Proof, error)
 }
 
+// privValidatorSelfTestMessage is the throwaway message SelfTestPrivValidator
+// proves. It can't be mistaken for a VRF message, which are hashes.
+var privValidatorSelfTestMessage = []byte("ostracon private validator self-test")
+
+// SelfTestPrivValidator checks that the private validator works before it's
+// used: its public key must have a well-formed address and, if the key type
+// supports VRF (ed25519), its VRF proof of a throwaway message must verify
+// against the public key. It doesn't sign any vote or proposal, which would
+// update the state of the private validator.
+func SelfTestPrivValidator(pv PrivValidator) error {
+	pubKey, err := pv.GetPubKey()
+	if err != nil {
+		return fmt.Errorf("can't get pubkey: %w", err)
+	}
+	if pubKey == nil {
+		return errors.New("the pubkey is nil")
+	}
+	if address := pubKey.Address(); len(address) != crypto.AddressSize {
+		return fmt.Errorf("the address %v of the pubkey %v has a wrong size: expected %d, got %d",
+			address, pubKey, crypto.AddressSize, len(address))
+	}
+
+	// only ed25519 keys support VRF
+	if pubKey.Type() != ed25519.KeyType {
+		return nil
+	}
+	proof, err := pv.GenerateVRFProof(privValidatorSelfTestMessage)
+	if err != nil {
+		return fmt.Errorf("can't generate a VRF proof: %w", err)
+	}
+	if _, err := pubKey.VRFVerify(proof, privValidatorSelfTestMessage); err != nil {
+		return fmt.Errorf("the VRF proof doesn't verify against the pubkey %v: %w", pubKey, err)
+	}
+	return nil
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
@@ -60,6 +97,19 @@ func NewMockPV() MockPV {
 	return MockPV{ed25519.GenPrivKey(), false, false}
 }
 
+// NewMockPVWithKeyType returns a MockPV with a new key of the given type,
+// ABCIPubKeyTypeEd25519 or ABCIPubKeyTypeSecp256k1. It panics on other types.
+func NewMockPVWithKeyType(keyType string) MockPV {
+	switch keyType {
+	case ABCIPubKeyTypeEd25519:
+		return MockPV{ed25519.GenPrivKey(), false, false}
+	case ABCIPubKeyTypeSecp256k1:
+		return MockPV{secp256k1.GenPrivKey(), false, false}
+	default:
+		panic(fmt.Sprintf("unsupported key type %q", keyType))
+	}
+}
+
 // NewMockPVWithParams allows one to create a MockPV instance, but with finer
 // grained control over the operation of the mock validator. This is useful for
 // mocking test failures.
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/line/ostracon/crypto"
+	"github.com/line/ostracon/crypto/ed25519"
+	"github.com/line/ostracon/crypto/secp256k1"
+)
+
+// brokenKeyPV is a MockPV whose pubkey isn't the one of its private key.
+type brokenKeyPV struct {
+	MockPV
+	pubKey crypto.PubKey
+}
+
+func (pv brokenKeyPV) GetPubKey() (crypto.PubKey, error) {
+	return pv.pubKey, nil
+}
+
+// badAddressPubKey is a pubkey whose address is malformed.
+type badAddressPubKey struct {
+	crypto.PubKey
+}
+
+func (badAddressPubKey) Address() crypto.Address {
+	return crypto.Address("short")
+}
+
+// brokenProofPV is a MockPV failing to generate VRF proofs.
+type brokenProofPV struct {
+	MockPV
+}
+
+func (pv brokenProofPV) GenerateVRFProof(message []byte) (crypto.Proof, error) {
+	return nil, errors.New("no HSM")
+}
+
+func TestSelfTestPrivValidator(t *testing.T) {
+	assert.NoError(t, SelfTestPrivValidator(NewMockPV()))
+	// the signing failures aren't exercised
+	assert.NoError(t, SelfTestPrivValidator(NewErroringMockPV()))
+
+	assert.Error(t, SelfTestPrivValidator(brokenKeyPV{NewMockPV(), ed25519.GenPrivKey().PubKey()}))
+	assert.Error(t, SelfTestPrivValidator(brokenProofPV{NewMockPV()}))
+
+	// the keys which don't support VRF are only checked for their address
+	secpPV := NewMockPVWithKeyType(ABCIPubKeyTypeSecp256k1)
+	assert.NoError(t, SelfTestPrivValidator(secpPV))
+	assert.Error(t, SelfTestPrivValidator(brokenKeyPV{secpPV, badAddressPubKey{secp256k1.GenPrivKey().PubKey()}}))
+}
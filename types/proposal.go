@@ -118,6 +118,15 @@ func ProposalSignBytes(chainID string, p *tmproto.Proposal) []byte {
 	return bz
 }
 
+// SignBytes returns the bytes the proposer signs for this proposal on the
+// given chain (see ProposalSignBytes).
+//
+// NOTE: the result is not cached since the proposal may still be mutated
+// (e.g. its timestamp) before being signed.
+func (p *Proposal) SignBytes(chainID string) []byte {
+	return ProposalSignBytes(chainID, p.ToProto())
+}
+
 // ToProto converts Proposal to protobuf
 func (p *Proposal) ToProto() *tmproto.Proposal {
 	if p == nil {
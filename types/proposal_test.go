@@ -132,6 +132,31 @@ func benchmarkProposalVerifySignature(b *testing.B) {
 	}
 }
 
+func TestProposalSignBytes(t *testing.T) {
+	chainID := "test_chain_id"
+	privVal := NewMockPV()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+
+	prop := NewProposal(
+		4, 2, 2,
+		BlockID{tmrand.Bytes(tmhash.Size), PartSetHeader{777, tmrand.Bytes(tmhash.Size)}})
+	assert.Equal(t, ProposalSignBytes(chainID, prop.ToProto()), prop.SignBytes(chainID))
+
+	p := prop.ToProto()
+	require.NoError(t, privVal.SignProposal(chainID, p))
+	prop.Signature = p.Signature
+
+	// the signature doesn't change the sign bytes and verifies against them
+	assert.True(t, pubKey.VerifySignature(prop.SignBytes(chainID), prop.Signature))
+	assert.False(t, pubKey.VerifySignature(prop.SignBytes("other_chain_id"), prop.Signature))
+
+	// after a proto round trip as well
+	np, err := ProposalFromProto(prop.ToProto())
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifySignature(np.SignBytes(chainID), np.Signature))
+}
+
 func TestProposalValidateBasic(t *testing.T) {
 
 	privVal := NewMockPV()
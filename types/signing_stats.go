@@ -0,0 +1,77 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// SigningCount is the number of commits a validator signed and missed.
+type SigningCount struct {
+	Address Address `json:"address"`
+	Signed  int64   `json:"signed"`
+	Missed  int64   `json:"missed"`
+}
+
+// MissRate returns the share of the commits the validator missed.
+func (c SigningCount) MissRate() float64 {
+	if total := c.Signed + c.Missed; total > 0 {
+		return float64(c.Missed) / float64(total)
+	}
+	return 0
+}
+
+// SigningStats accumulates the number of commits each validator signed and
+// missed, e.g. to detect the validators that are consistently offline. A
+// signature for nil counts as signed: the validator was live.
+type SigningStats struct {
+	counts map[string]*SigningCount
+}
+
+// NewSigningStats returns empty signing stats.
+func NewSigningStats() *SigningStats {
+	return &SigningStats{counts: make(map[string]*SigningCount)}
+}
+
+// AddCommit counts the signatures of the given commit, signed by vals.
+func (s *SigningStats) AddCommit(vals *ValidatorSet, commit *Commit) error {
+	if vals.Size() != len(commit.Signatures) {
+		return NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
+	}
+	signed := commit.BitArray()
+	for idx, val := range vals.Validators {
+		count, ok := s.counts[string(val.Address)]
+		if !ok {
+			count = &SigningCount{Address: val.Address}
+			s.counts[string(val.Address)] = count
+		}
+		if signed.GetIndex(idx) {
+			count.Signed++
+		} else {
+			count.Missed++
+		}
+	}
+	return nil
+}
+
+// Counts returns the counts of all the validators seen so far, sorted by
+// address.
+func (s *SigningStats) Counts() []SigningCount {
+	counts := make([]SigningCount, 0, len(s.counts))
+	for _, count := range s.counts {
+		counts = append(counts, *count)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return bytes.Compare(counts[i].Address, counts[j].Address) < 0
+	})
+	return counts
+}
+
+// Count returns the count of the validator with the given address.
+func (s *SigningStats) Count(address Address) (SigningCount, error) {
+	count, ok := s.counts[string(address)]
+	if !ok {
+		return SigningCount{}, fmt.Errorf("no commit of validator %v", address)
+	}
+	return *count, nil
+}
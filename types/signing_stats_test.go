@@ -0,0 +1,66 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestSigningStats(t *testing.T) {
+	const offline = 2
+	stats := NewSigningStats()
+
+	_, valSet, vals := randVoteSet(1, 0, tmproto.PrecommitType, 7, 10)
+	for h := int64(1); h <= 5; h++ {
+		voteSet := NewVoteSet("test_chain_id", h, 0, tmproto.PrecommitType, valSet)
+		blockID := makeBlockIDRandom()
+		for i, val := range vals {
+			if i == offline {
+				continue
+			}
+			pubKey, err := val.GetPubKey()
+			require.NoError(t, err)
+			vote := &Vote{
+				ValidatorAddress: pubKey.Address(),
+				ValidatorIndex:   int32(i),
+				Height:           h,
+				Round:            0,
+				Type:             tmproto.PrecommitType,
+				BlockID:          blockID,
+				Timestamp:        time.Now(),
+			}
+			if i == 3 && h%2 == 0 {
+				vote.BlockID = BlockID{} // a vote for nil is still signed
+			}
+			_, err = signAddVote(val, vote, voteSet)
+			require.NoError(t, err)
+		}
+		require.NoError(t, stats.AddCommit(valSet, voteSet.MakeCommit()))
+	}
+
+	counts := stats.Counts()
+	require.Len(t, counts, 7)
+	for i := 1; i < len(counts); i++ {
+		assert.Less(t, string(counts[i-1].Address), string(counts[i].Address))
+	}
+	for i, val := range valSet.Validators {
+		count, err := stats.Count(val.Address)
+		require.NoError(t, err)
+		if i == offline {
+			assert.Equal(t, SigningCount{Address: val.Address, Signed: 0, Missed: 5}, count)
+			assert.Equal(t, 1.0, count.MissRate())
+		} else {
+			assert.Equal(t, SigningCount{Address: val.Address, Signed: 5, Missed: 0}, count)
+			assert.Equal(t, 0.0, count.MissRate())
+		}
+	}
+
+	_, err := stats.Count(Address("unknown"))
+	assert.Error(t, err)
+	assert.Error(t, stats.AddCommit(valSet, &Commit{Signatures: []CommitSig{NewCommitSigAbsent()}}))
+	assert.Equal(t, 0.0, SigningCount{}.MissRate())
+}
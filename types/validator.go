@@ -180,7 +180,14 @@ func ValidatorFromProto(vp *tmproto.Validator) (*Validator, error) {
 // RandValidator returns a randomized validator, useful for testing.
 // UNSTABLE
 func RandValidator(randPower bool, minPower int64) (*Validator, PrivValidator) {
-	privVal := NewMockPV()
+	return RandValidatorWithKeyType(randPower, minPower, ABCIPubKeyTypeEd25519)
+}
+
+// RandValidatorWithKeyType is the same as RandValidator, but with a key of the
+// given type (see NewMockPVWithKeyType).
+// UNSTABLE
+func RandValidatorWithKeyType(randPower bool, minPower int64, keyType string) (*Validator, PrivValidator) {
+	privVal := NewMockPVWithKeyType(keyType)
 	votingPower := minPower
 	if randPower {
 		votingPower += int64(tmrand.Uint32())
@@ -7,14 +7,21 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
+	"github.com/line/ostracon/crypto"
+	cryptoenc "github.com/line/ostracon/crypto/encoding"
 	"github.com/line/ostracon/crypto/merkle"
 	"github.com/line/ostracon/crypto/tmhash"
 	tmmath "github.com/line/ostracon/libs/math"
+	tmrand "github.com/line/ostracon/libs/rand"
 )
 
 const (
@@ -57,6 +64,9 @@ type ValidatorSet struct {
 
 	// cached (unexported)
 	totalVotingPower int64
+
+	// set by Freeze
+	frozen bool
 }
 
 // NewValidatorSet initializes a ValidatorSet by copying over the values from
@@ -71,10 +81,38 @@ type ValidatorSet struct {
 // validation.
 func NewValidatorSet(valz []*Validator) *ValidatorSet {
 	vals := &ValidatorSet{}
-	err := vals.updateWithChangeSet(valz, false)
+	err := vals.updateWithChangeSet(valz, false, 0)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot create validator set: %v", err))
+	}
+	return vals
+}
+
+// NewValidatorSetNoPriority initializes a ValidatorSet like NewValidatorSet,
+// but skips the proposer priority bookkeeping: the ProposerPriority of each
+// validator is copied as is and never rescaled or centered. Such a set is meant
+// for verification-only use (e.g. VerifyCommit), which does not depend on
+// priorities.
+//
+// The addresses of validators in `valz` must be unique and their voting power
+// positive, otherwise the function panics.
+func NewValidatorSetNoPriority(valz []*Validator) *ValidatorSet {
+	vals := &ValidatorSet{}
+	if len(valz) == 0 {
+		return vals
+	}
+
+	updates, deletes, err := processChanges(valz)
+	if err == nil && len(deletes) != 0 {
+		err = fmt.Errorf("cannot process validators with voting power 0: %v", deletes)
+	}
 	if err != nil {
 		panic(fmt.Sprintf("Cannot create validator set: %v", err))
 	}
+
+	vals.Validators = updates
+	vals.updateTotalVotingPower() // will panic if total voting power > MaxTotalVotingPower
+	sort.Sort(ValidatorsByVotingPower(vals.Validators))
 	return vals
 }
 
@@ -111,6 +149,7 @@ func (vals *ValidatorSet) CopyIncrementProposerPriority(times int32) *ValidatorS
 // proposer. Panics if validator set is empty.
 // `times` must be positive.
 func (vals *ValidatorSet) IncrementProposerPriority(times int32) {
+	vals.checkNotFrozen()
 	if vals.IsNilOrEmpty() {
 		panic("empty validator set")
 	}
@@ -134,6 +173,7 @@ func (vals *ValidatorSet) IncrementProposerPriority(times int32) {
 // RescalePriorities rescales the priorities such that the distance between the maximum and minimum
 // is smaller than `diffMax`.
 func (vals *ValidatorSet) RescalePriorities(diffMax int64) {
+	vals.checkNotFrozen()
 	if vals.IsNilOrEmpty() {
 		panic("empty validator set")
 	}
@@ -238,6 +278,26 @@ func validatorListCopy(valsList []*Validator) []*Validator {
 	return valsCopy
 }
 
+// Freeze marks the set as read-only and returns it: from then on, its mutating
+// methods (UpdateWithChangeSet, IncrementProposerPriority, ...) panic. It is
+// meant to catch accidental mutations of a shared set; the Validators slice
+// itself is not protected. Copy returns a mutable copy of a frozen set.
+func (vals *ValidatorSet) Freeze() *ValidatorSet {
+	vals.frozen = true
+	return vals
+}
+
+// IsFrozen returns true if Freeze has been called on the set.
+func (vals *ValidatorSet) IsFrozen() bool {
+	return vals.frozen
+}
+
+func (vals *ValidatorSet) checkNotFrozen() {
+	if vals != nil && vals.frozen {
+		panic("cannot mutate a frozen validator set")
+	}
+}
+
 // Copy each validator into a new ValidatorSet.
 func (vals *ValidatorSet) Copy() *ValidatorSet {
 	return &ValidatorSet{
@@ -288,19 +348,30 @@ func (vals *ValidatorSet) Size() int {
 // Forces recalculation of the set's total voting power.
 // Panics if total voting power is bigger than MaxTotalVotingPower.
 func (vals *ValidatorSet) updateTotalVotingPower() {
+	sum, err := vals.sumVotingPower()
+	if err != nil {
+		panic(fmt.Sprintf(
+			"Total voting power should be guarded to not exceed %v; got: %v",
+			MaxTotalVotingPower,
+			sum))
+	}
+
+	vals.totalVotingPower = sum
+}
+
+// sumVotingPower returns the sum of the voting powers of all validators, or
+// ErrTotalVotingPowerOverflow along with the partial sum which exceeded
+// MaxTotalVotingPower.
+func (vals *ValidatorSet) sumVotingPower() (int64, error) {
 	sum := int64(0)
 	for _, val := range vals.Validators {
 		// mind overflow
 		sum = safeAddClip(sum, val.VotingPower)
 		if sum > MaxTotalVotingPower {
-			panic(fmt.Sprintf(
-				"Total voting power should be guarded to not exceed %v; got: %v",
-				MaxTotalVotingPower,
-				sum))
+			return sum, ErrTotalVotingPowerOverflow
 		}
 	}
-
-	vals.totalVotingPower = sum
+	return sum, nil
 }
 
 // TotalVotingPower returns the sum of the voting powers of all validators.
@@ -312,14 +383,323 @@ func (vals *ValidatorSet) TotalVotingPower() int64 {
 	return vals.totalVotingPower
 }
 
+// IsSuperMajorityFor returns true if the given voting power is more than 2/3
+// of the total voting power of the set, i.e. enough to commit a block.
+func (vals *ValidatorSet) IsSuperMajorityFor(power int64) bool {
+	return power > twoThirds(vals.TotalVotingPower())
+}
+
+// twoThirds returns 2/3 of the voting power, rounded down, without computing
+// power*2, which could overflow.
+func twoThirds(power int64) int64 {
+	return power/3*2 + power%3*2/3
+}
+
+// TryTotalVotingPower is the same as TotalVotingPower, but returns
+// ErrTotalVotingPowerOverflow instead of panicking if the total voting power
+// exceeds MaxTotalVotingPower. Meant for code paths, such as RPC, that must not
+// crash.
+func (vals *ValidatorSet) TryTotalVotingPower() (int64, error) {
+	if vals.totalVotingPower == 0 {
+		sum, err := vals.sumVotingPower()
+		if err != nil {
+			return 0, err
+		}
+		vals.totalVotingPower = sum
+	}
+	return vals.totalVotingPower, nil
+}
+
+// ByzantinePower returns the total voting power of the validators with the
+// given addresses, e.g. the byzantine validators of light client attack
+// evidence. It returns an error if an address is not in the set or is listed
+// more than once.
+func (vals *ValidatorSet) ByzantinePower(byzantineAddrs [][]byte) (int64, error) {
+	var (
+		power int64
+		seen  = make(map[string]bool, len(byzantineAddrs))
+	)
+	for _, addr := range byzantineAddrs {
+		if seen[string(addr)] {
+			return 0, fmt.Errorf("duplicate byzantine validator %X", addr)
+		}
+		seen[string(addr)] = true
+
+		_, val := vals.GetByAddress(addr)
+		if val == nil {
+			return 0, fmt.Errorf("byzantine validator %X is not in the validator set", addr)
+		}
+		power = safeAddClip(power, val.VotingPower)
+	}
+	return power, nil
+}
+
+// PowerShare returns the fraction of the total voting power each validator
+// holds, keyed by the string of its address (Address.String). The shares sum
+// to 1, up to float rounding. It returns an empty map for an empty set.
+func (vals *ValidatorSet) PowerShare() map[string]float64 {
+	shares := make(map[string]float64, vals.Size())
+	if vals.IsNilOrEmpty() {
+		return shares
+	}
+	total := float64(vals.TotalVotingPower())
+	for _, val := range vals.Validators {
+		shares[val.Address.String()] = float64(val.VotingPower) / total
+	}
+	return shares
+}
+
+// SelectionProbabilities returns the probability of each validator to be
+// selected as the proposer of a round by SelectProposer, keyed by the string
+// of its address. The selection samples a validator with a uniform random
+// point of the total voting power, so the probability of a validator is its
+// share of the voting power (see PowerShare), up to the 2^-63 precision of
+// the sampling.
+func (vals *ValidatorSet) SelectionProbabilities() map[string]float64 {
+	return vals.PowerShare()
+}
+
+// ExportPriorities returns the proposer priority of each validator, keyed by
+// the string of its address, e.g. to persist them across restarts.
+func (vals *ValidatorSet) ExportPriorities() map[string]int64 {
+	priorities := make(map[string]int64, vals.Size())
+	if vals == nil {
+		return priorities
+	}
+	for _, val := range vals.Validators {
+		priorities[val.Address.String()] = val.ProposerPriority
+	}
+	return priorities
+}
+
+// ImportPriorities sets the proposer priority of each validator from the
+// given map, as returned by ExportPriorities. It returns an error and leaves
+// the set unchanged unless the map has exactly the addresses of the set. It
+// panics if the set is frozen.
+func (vals *ValidatorSet) ImportPriorities(priorities map[string]int64) error {
+	vals.checkNotFrozen()
+	if len(priorities) != vals.Size() {
+		return fmt.Errorf("got priorities of %d validators, expected %d", len(priorities), vals.Size())
+	}
+	for _, val := range vals.Validators {
+		if _, ok := priorities[val.Address.String()]; !ok {
+			return fmt.Errorf("no priority for validator %v", val.Address)
+		}
+	}
+	for _, val := range vals.Validators {
+		val.ProposerPriority = priorities[val.Address.String()]
+	}
+	return nil
+}
+
+// CheckPriorityInvariants returns an error if the proposer priorities of the
+// validators are inconsistent, e.g. after deserializing the set: their sum must
+// be centered around 0, within (-n, n) for n validators, and their spread must
+// not exceed PriorityWindowSizeFactor times the total voting power.
+func (vals *ValidatorSet) CheckPriorityInvariants() error {
+	if vals.IsNilOrEmpty() {
+		return nil
+	}
+	tvp, err := vals.sumVotingPower()
+	if err != nil {
+		return err
+	}
+
+	n := int64(len(vals.Validators))
+	var sum int64
+	for _, val := range vals.Validators {
+		sum = safeAddClip(sum, val.ProposerPriority)
+	}
+	if sum <= -n || sum >= n {
+		return fmt.Errorf("proposer priorities are not centered: total priority %d, expected in (-%d, %d)",
+			sum, n, n)
+	}
+
+	window := PriorityWindowSizeFactor * tvp
+	if diff := computeMaxMinPriorityDiff(vals); diff > window {
+		return fmt.Errorf("proposer priorities exceed the window: distance %d, expected at most %d",
+			diff, window)
+	}
+	return nil
+}
+
+// WeightedMedian returns the median of the given values of the validators,
+// keyed by the string of their address, weighted by their voting power: the
+// least value such that the validators with a lower or equal value hold at
+// least half of the total voting power. It returns an error if the set is
+// empty or a validator has no value. The values of other addresses are
+// ignored.
+func (vals *ValidatorSet) WeightedMedian(values map[string]int64) (int64, error) {
+	if vals.IsNilOrEmpty() {
+		return 0, errors.New("empty validator set")
+	}
+
+	type weightedValue struct {
+		value int64
+		power int64
+	}
+	weighted := make([]weightedValue, len(vals.Validators))
+	for i, val := range vals.Validators {
+		value, ok := values[val.Address.String()]
+		if !ok {
+			return 0, fmt.Errorf("no value for validator %v", val.Address)
+		}
+		weighted[i] = weightedValue{value, val.VotingPower}
+	}
+	sort.SliceStable(weighted, func(i, j int) bool { return weighted[i].value < weighted[j].value })
+
+	var power int64
+	total := vals.TotalVotingPower()
+	for _, wv := range weighted[:len(weighted)-1] {
+		power += wv.power
+		if power*2 >= total {
+			return wv.value, nil
+		}
+	}
+	return weighted[len(weighted)-1].value, nil
+}
+
+// PowerIndex is a precomputed index of the voting powers of a validator set
+// for answering many quorum questions against the same set. It's a snapshot:
+// later changes of the set are not reflected.
+type PowerIndex struct {
+	powers map[string]int64
+	// cumulative[k] is the voting power of the k+1 most powerful validators
+	cumulative []int64
+	needed     int64
+}
+
+// CumulativePowerIndex returns the PowerIndex of the set.
+func (vals *ValidatorSet) CumulativePowerIndex() *PowerIndex {
+	idx := &PowerIndex{
+		powers:     make(map[string]int64, vals.Size()),
+		cumulative: make([]int64, vals.Size()),
+	}
+	if vals.IsNilOrEmpty() {
+		return idx
+	}
+	powers := make([]int64, len(vals.Validators))
+	for i, val := range vals.Validators {
+		idx.powers[string(val.Address)] = val.VotingPower
+		powers[i] = val.VotingPower
+	}
+	sort.Slice(powers, func(i, j int) bool { return powers[i] > powers[j] })
+	var sum int64
+	for i, power := range powers {
+		sum = safeAddClip(sum, power)
+		idx.cumulative[i] = sum
+	}
+	idx.needed = twoThirds(vals.TotalVotingPower())
+	return idx
+}
+
+// ReachesQuorum returns true if the validators with the given addresses hold
+// more than 2/3 of the total voting power, as VerifyCommit requires. Unknown
+// and duplicate addresses are ignored. Too few addresses to reach the quorum,
+// even as the most powerful validators, are rejected without a lookup.
+func (idx *PowerIndex) ReachesQuorum(addrs [][]byte) bool {
+	if len(idx.cumulative) == 0 || len(addrs) == 0 {
+		return false
+	}
+	k := len(addrs)
+	if k > len(idx.cumulative) {
+		k = len(idx.cumulative)
+	}
+	if idx.cumulative[k-1] <= idx.needed {
+		return false
+	}
+
+	var (
+		power int64
+		seen  = make(map[string]bool, len(addrs))
+	)
+	for _, addr := range addrs {
+		if seen[string(addr)] {
+			continue
+		}
+		seen[string(addr)] = true
+		power += idx.powers[string(addr)]
+		if power > idx.needed {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatorSetDiff is the difference between two validator sets.
+type ValidatorSetDiff struct {
+	// the validators of the new set only
+	Added []*Validator
+	// the validators of the old set only
+	Removed []*Validator
+	// the validators of both sets whose voting power changed, as in the new set
+	Updated []*Validator
+}
+
+// IsEmpty returns true if both sets have the same validators with the same
+// voting powers.
+func (diff ValidatorSetDiff) IsEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Updated) == 0
+}
+
+// Diff returns the validators that joined, left or changed their voting power
+// from vals to newVals, in the order of their sets. The validators are copies.
+// The proposer priorities are ignored.
+func (vals *ValidatorSet) Diff(newVals *ValidatorSet) ValidatorSetDiff {
+	var diff ValidatorSetDiff
+	for _, val := range newVals.Validators {
+		_, oldVal := vals.GetByAddress(val.Address)
+		switch {
+		case oldVal == nil:
+			diff.Added = append(diff.Added, val.Copy())
+		case oldVal.VotingPower != val.VotingPower:
+			diff.Updated = append(diff.Updated, val.Copy())
+		}
+	}
+	for _, val := range vals.Validators {
+		if !newVals.HasAddress(val.Address) {
+			diff.Removed = append(diff.Removed, val.Copy())
+		}
+	}
+	return diff
+}
+
+// The versions of the validator set hash (see HashWithVersion).
+const (
+	// ValidatorSetHashV1 is the Merkle root hash of the MerkleLeaves.
+	ValidatorSetHashV1 = 1
+
+	// LatestValidatorSetHashVersion is the version of Hash.
+	LatestValidatorSetHashVersion = ValidatorSetHashV1
+)
+
 // Hash returns the Merkle root hash build using validators (as leaves) in the
 // set.
 func (vals *ValidatorSet) Hash() []byte {
+	return vals.HashWithVersion(LatestValidatorSetHashVersion)
+}
+
+// HashWithVersion returns the hash of the set computed as the given version
+// did, or nil if the version is unknown. The hash is consensus-critical: a new
+// version must be added rather than changing an existing one.
+func (vals *ValidatorSet) HashWithVersion(v int) []byte {
+	switch v {
+	case ValidatorSetHashV1:
+		return merkle.HashFromByteSlices(vals.MerkleLeaves())
+	default:
+		return nil
+	}
+}
+
+// MerkleLeaves returns the leaves Hash is computed from: the encoding of each
+// validator (Validator.Bytes), in the order of the set, i.e. by descending
+// voting power then ascending address.
+func (vals *ValidatorSet) MerkleLeaves() [][]byte {
 	bzs := make([][]byte, len(vals.Validators))
 	for i, val := range vals.Validators {
 		bzs[i] = val.Bytes()
 	}
-	return merkle.HashFromByteSlices(bzs)
+	return bzs
 }
 
 // Iterate will run the given function over the set.
@@ -554,7 +934,9 @@ func (vals *ValidatorSet) applyRemovals(deletes []*Validator) {
 // If 'allowDeletes' is false then delete operations (identified by validators with voting power 0)
 // are not allowed and will trigger an error if present in 'changes'.
 // The 'allowDeletes' flag is set to false by NewValidatorSet() and to true by UpdateWithChangeSet().
-func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes bool) error {
+// If 'maxSize' is positive, changes resulting in a set of more than 'maxSize' validators trigger an error.
+func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes bool, maxSize int) error {
+	vals.checkNotFrozen()
 	if len(changes) == 0 {
 		return nil
 	}
@@ -574,6 +956,14 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 		return errors.New("applying the validator changes would result in empty set")
 	}
 
+	// Check that the resulting set will not be too large.
+	if maxSize > 0 {
+		if size := len(vals.Validators) + numNewValidators(updates, vals) - len(deletes); size > maxSize {
+			return fmt.Errorf("applying the validator changes would result in %d validators, more than the max %d",
+				size, maxSize)
+		}
+	}
+
 	// Verify that applying the 'deletes' against 'vals' will not result in error.
 	// Get the voting power that is going to be removed.
 	removedVotingPower, err := verifyRemovals(deletes, vals)
@@ -606,6 +996,32 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 	return nil
 }
 
+// MergeChangeSets merges the change sets a and b into a single change set,
+// sorted by address: the changes of b override the changes of a to the same
+// validators. It returns an error if a change set has duplicate entries. The
+// change sets aren't modified.
+func MergeChangeSets(a, b []*Validator) ([]*Validator, error) {
+	merged := make(map[string]*Validator, len(a)+len(b))
+	for _, changes := range [][]*Validator{a, b} {
+		seen := make(map[string]bool, len(changes))
+		for _, change := range changes {
+			address := string(change.Address)
+			if seen[address] {
+				return nil, fmt.Errorf("duplicate entry %v in %v", change, changes)
+			}
+			seen[address] = true
+			merged[address] = change.Copy()
+		}
+	}
+
+	changes := make([]*Validator, 0, len(merged))
+	for _, change := range merged {
+		changes = append(changes, change)
+	}
+	sort.Sort(ValidatorsByAddress(changes))
+	return changes, nil
+}
+
 // UpdateWithChangeSet attempts to update the validator set with 'changes'.
 // It performs the following steps:
 // - validates the changes making sure there are no duplicates and splits them in updates and deletes
@@ -619,7 +1035,134 @@ func (vals *ValidatorSet) updateWithChangeSet(changes []*Validator, allowDeletes
 // If an error is detected during verification steps, it is returned and the validator set
 // is not changed.
 func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
-	return vals.updateWithChangeSet(changes, true)
+	return vals.updateWithChangeSet(changes, true, 0)
+}
+
+// UpdateWithChangeSetMaxSize is the same as UpdateWithChangeSet, but also
+// returns an error, leaving the set unchanged, if applying the changes would
+// result in more than maxSize validators. A non-positive maxSize means no limit.
+func (vals *ValidatorSet) UpdateWithChangeSetMaxSize(changes []*Validator, maxSize int) error {
+	return vals.updateWithChangeSet(changes, true, maxSize)
+}
+
+// ApplyValidatorUpdates converts the given ABCI validator updates and applies
+// them to the set with UpdateWithChangeSet. Either all updates are applied or,
+// if any of them can't be converted (e.g. unsupported public key type) or
+// applied, none is and the error is returned.
+func (vals *ValidatorSet) ApplyValidatorUpdates(updates []abci.ValidatorUpdate) error {
+	changes := make([]*Validator, len(updates))
+	for i, update := range updates {
+		pubKey, err := cryptoenc.PubKeyFromProto(&update.PubKey)
+		if err != nil {
+			return fmt.Errorf("invalid validator update #%d: %w", i, err)
+		}
+		changes[i] = NewValidator(pubKey, update.Power)
+	}
+	return vals.UpdateWithChangeSet(changes)
+}
+
+// ValidatorSetFromInitChain converts the validators returned by the app in
+// ResponseInitChain and creates the initial validator set from them, like
+// NewValidatorSet does for the genesis validators. Unlike NewValidatorSet, it
+// returns an error if there's no validator or any of them can't be converted
+// (e.g. unsupported public key type) or is invalid (e.g. non-positive power).
+func ValidatorSetFromInitChain(abciVals []abci.ValidatorUpdate) (*ValidatorSet, error) {
+	if len(abciVals) == 0 {
+		return nil, errors.New("no validators in InitChain response")
+	}
+	valz := make([]*Validator, len(abciVals))
+	for i, abciVal := range abciVals {
+		pubKey, err := cryptoenc.PubKeyFromProto(&abciVal.PubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator #%d: %w", i, err)
+		}
+		valz[i] = NewValidator(pubKey, abciVal.Power)
+	}
+	vals := &ValidatorSet{}
+	if err := vals.updateWithChangeSet(valz, false, 0); err != nil {
+		return nil, fmt.Errorf("invalid validators: %w", err)
+	}
+	return vals, nil
+}
+
+// NextValidatorSetHash returns the hash of the validator set resulting from
+// applying the given ABCI validator updates to current, as ApplyValidatorUpdates
+// would, without mutating current.
+func NextValidatorSetHash(current *ValidatorSet, updates []abci.ValidatorUpdate) ([]byte, error) {
+	next := current.Copy()
+	if err := next.ApplyValidatorUpdates(updates); err != nil {
+		return nil, err
+	}
+	return next.Hash(), nil
+}
+
+// ChangeSetBetween returns the minimal list of changes transforming from into
+// to with UpdateWithChangeSet: the validators of to that are not in from or
+// whose voting power or public key differ, and the validators of from that are
+// not in to, with a voting power of 0 to remove them. The changes are sorted
+// by address. Note that the proposer priorities are not carried over, and that
+// the changes can't be applied if to is empty.
+func ChangeSetBetween(from, to *ValidatorSet) []*Validator {
+	var changes []*Validator
+	for _, val := range to.Validators {
+		_, existing := from.GetByAddress(val.Address)
+		if existing == nil || existing.VotingPower != val.VotingPower || !pubKeysEqual(existing.PubKey, val.PubKey) {
+			changes = append(changes, &Validator{Address: val.Address, PubKey: val.PubKey, VotingPower: val.VotingPower})
+		}
+	}
+	for _, val := range from.Validators {
+		if !to.HasAddress(val.Address) {
+			changes = append(changes, &Validator{Address: val.Address, PubKey: val.PubKey, VotingPower: 0})
+		}
+	}
+	sort.Sort(ValidatorsByAddress(changes))
+	return changes
+}
+
+func pubKeysEqual(a, b crypto.PubKey) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equals(b)
+}
+
+// MatchesCommitValidators returns true if the commit may have been signed by
+// the set: it has a signature for each validator and the addresses of the
+// signatures present are the ones of the validators at the same index. It is a
+// cheap check to fail fast before verifying the signatures of a commit.
+func (vals *ValidatorSet) MatchesCommitValidators(commit *Commit) bool {
+	if commit == nil || len(commit.Signatures) != vals.Size() {
+		return false
+	}
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.Absent() {
+			continue
+		}
+		if !bytes.Equal(commitSig.ValidatorAddress, vals.Validators[idx].Address) {
+			return false
+		}
+	}
+	return true
+}
+
+// SignedPower returns the voting power of the validators whose signature of the
+// commit is for the block, as tallied by VerifyCommit, and the total voting
+// power of the set, e.g. to measure the liveness of the set. It returns an
+// error if the commit doesn't match the set (see MatchesCommitValidators). The
+// signatures are not verified.
+func (vals *ValidatorSet) SignedPower(commit *Commit) (signed, total int64, err error) {
+	if commit == nil {
+		return 0, 0, errors.New("nil commit")
+	}
+	if err := vals.verifyCommitBasic(commit.BlockID, commit.Height, commit); err != nil {
+		return 0, 0, err
+	}
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.ForBlock() {
+			signed += vals.Validators[idx].VotingPower
+		}
+	}
+	return signed, vals.TotalVotingPower(), nil
 }
 
 // VerifyCommit verifies +2/3 of the set had signed the given commit.
@@ -632,25 +1175,12 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 	height int64, commit *Commit) error {
 
-	if vals == nil || commit == nil {
-		return fmt.Errorf("invalid nil vals or commit:[%v] or [%v]", vals, commit)
-	}
-
-	if vals.Size() != len(commit.Signatures) {
-		return NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
-	}
-
-	// Validate Height and BlockID.
-	if height != commit.Height {
-		return NewErrInvalidCommitHeight(height, commit.Height)
-	}
-	if !blockID.Equals(commit.BlockID) {
-		return fmt.Errorf("invalid commit -- wrong block ID: want %v, got %v",
-			blockID, commit.BlockID)
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
 	}
 
 	talliedVotingPower := int64(0)
-	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3 // FIXME: 🏺 arithmetic overflow
+	votingPowerNeeded := twoThirds(vals.TotalVotingPower())
 	for idx, commitSig := range commit.Signatures {
 		if commitSig.Absent() {
 			continue // OK, some signatures can be absent.
@@ -682,15 +1212,92 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
 	return nil
 }
 
-// LIGHT CLIENT VERIFICATION METHODS
+// VerifyCommitWithTimestampTolerance is the same as VerifyCommit, but also
+// rejects the commit if the timestamp of any of its signatures deviates by more
+// than maxDrift from blockTime, the time of the committed block. A non-positive
+// maxDrift disables the check, making it equivalent to VerifyCommit.
+func (vals *ValidatorSet) VerifyCommitWithTimestampTolerance(chainID string, blockID BlockID,
+	height int64, commit *Commit, blockTime time.Time, maxDrift time.Duration) error {
+
+	if maxDrift > 0 && commit != nil {
+		for idx, commitSig := range commit.Signatures {
+			if commitSig.Absent() {
+				continue
+			}
+			drift := commitSig.Timestamp.Sub(blockTime)
+			if drift < 0 {
+				drift = -drift
+			}
+			if drift > maxDrift {
+				return fmt.Errorf("timestamp of signature #%d (%v) deviates from block time %v by more than %v",
+					idx, commitSig.Timestamp, blockTime, maxDrift)
+			}
+		}
+	}
 
-// VerifyCommitLight verifies +2/3 of the set had signed the given commit.
-//
-// This method is primarily used by the light client and does not check all the
-// signatures.
-func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
-	height int64, commit *Commit) error {
+	return vals.VerifyCommit(chainID, blockID, height, commit)
+}
+
+// VerifyCommitParallel is the same as VerifyCommit, but checks the signatures
+// concurrently on a pool of `workers` goroutines (runtime.GOMAXPROCS(0) if not
+// positive). Its verdict and errors are identical to VerifyCommit's: when
+// several signatures are wrong, the one with the lowest index is reported.
+func (vals *ValidatorSet) VerifyCommitParallel(chainID string, blockID BlockID,
+	height int64, commit *Commit, workers int) error {
+
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
 
+	// Each worker only writes the entries of the indexes it receives.
+	verified := make([]bool, len(commit.Signatures))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
+				verified[idx] = vals.Validators[idx].PubKey.VerifySignature(voteSignBytes, commit.Signatures[idx].Signature)
+			}
+		}()
+	}
+	for idx, commitSig := range commit.Signatures {
+		if !commitSig.Absent() {
+			indexes <- idx
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	talliedVotingPower := int64(0)
+	votingPowerNeeded := twoThirds(vals.TotalVotingPower())
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.Absent() {
+			continue // OK, some signatures can be absent.
+		}
+		if !verified[idx] {
+			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		}
+		if commitSig.ForBlock() {
+			talliedVotingPower += vals.Validators[idx].VotingPower
+		}
+	}
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	return nil
+}
+
+// verifyCommitBasic checks the commit matches the set, height and block ID.
+func (vals *ValidatorSet) verifyCommitBasic(blockID BlockID, height int64, commit *Commit) error {
 	if vals == nil || commit == nil {
 		return fmt.Errorf("invalid nil vals or commit:[%v] or [%v]", vals, commit)
 	}
@@ -708,8 +1315,39 @@ func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
 			blockID, commit.BlockID)
 	}
 
+	// Each signature is tallied for the validator at its index, so it must
+	// claim that validator: otherwise, the signatures of a validator could be
+	// repeated under the indices of others.
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.Absent() {
+			continue
+		}
+		if !bytes.Equal(commitSig.ValidatorAddress, vals.Validators[idx].Address) {
+			return fmt.Errorf("wrong validator address (#%d): want %v, got %v",
+				idx, vals.Validators[idx].Address, commitSig.ValidatorAddress)
+		}
+	}
+
+	return nil
+}
+
+// LIGHT CLIENT VERIFICATION METHODS
+
+// VerifyCommitLight verifies +2/3 of the set had signed the given commit.
+//
+// This method is primarily used by the light client and does not check all the
+// signatures: it returns as soon as +2/3 of the total voting power is tallied.
+// It assumes the commit was produced by this very validator set (signatures
+// and validators have a 1-to-1 correspondence by index).
+func (vals *ValidatorSet) VerifyCommitLight(chainID string, blockID BlockID,
+	height int64, commit *Commit) error {
+
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
+	}
+
 	talliedVotingPower := int64(0)
-	votingPowerNeeded := vals.TotalVotingPower() * 2 / 3 // FIXME: 🏺 arithmetic overflow
+	votingPowerNeeded := twoThirds(vals.TotalVotingPower())
 	for idx, commitSig := range commit.Signatures {
 		// No need to verify absent or nil votes.
 		if !commitSig.ForBlock() {
@@ -798,18 +1436,243 @@ func (vals *ValidatorSet) VerifyCommitLightTrusting(chainID string, commit *Comm
 	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
 }
 
+// VerifyCommitPartialTrust verifies that trustLevel of the voting power of the
+// trusted subset of the set, given by their addresses, signed the given
+// commit. The signatures of the other validators aren't tallied. The addresses
+// out of the set are ignored, but the set must have a trusted validator.
+//
+// As with VerifyCommitLight, the commit must be produced by this very
+// validator set, and it returns as soon as enough voting power is tallied.
+func (vals *ValidatorSet) VerifyCommitPartialTrust(chainID string, blockID BlockID,
+	height int64, commit *Commit, trustedSubset [][]byte, trustLevel tmmath.Fraction) error {
+
+	// sanity check
+	if trustLevel.Denominator == 0 {
+		return errors.New("trustLevel has zero Denominator")
+	}
+	if err := vals.verifyCommitBasic(blockID, height, commit); err != nil {
+		return err
+	}
+
+	trusted := make(map[string]bool, len(trustedSubset))
+	for _, address := range trustedSubset {
+		trusted[string(address)] = true
+	}
+	var trustedVotingPower int64
+	for _, val := range vals.Validators {
+		if trusted[string(val.Address)] {
+			trustedVotingPower += val.VotingPower
+		}
+	}
+	if trustedVotingPower == 0 {
+		return errors.New("no trusted validator in the validator set")
+	}
+
+	// Safely calculate voting power needed.
+	trustedVotingPowerMulByNumerator, overflow := safeMul(trustedVotingPower, int64(trustLevel.Numerator))
+	if overflow {
+		return errors.New("int64 overflow while calculating voting power needed. " + "please provide smaller trustLevel numerator")
+	}
+	votingPowerNeeded := trustedVotingPowerMulByNumerator / int64(trustLevel.Denominator)
+
+	talliedVotingPower := int64(0)
+	for idx, commitSig := range commit.Signatures {
+		// No need to verify absent or nil votes.
+		if !commitSig.ForBlock() {
+			continue
+		}
+
+		val := vals.Validators[idx]
+		if !trusted[string(val.Address)] {
+			continue
+		}
+
+		// Validate signature.
+		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
+		if !val.PubKey.VerifySignature(voteSignBytes, commitSig.Signature) {
+			return fmt.Errorf("wrong signature (#%d): %X", idx, commitSig.Signature)
+		}
+
+		talliedVotingPower += val.VotingPower
+
+		if talliedVotingPower > votingPowerNeeded {
+			return nil
+		}
+	}
+
+	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+}
+
 func (vals *ValidatorSet) SelectProposer(proofHash []byte, height int64, round int32) *Validator {
+	return vals.SelectProposerDetailed(proofHash, height, round).Proposer
+}
+
+// ProposerSelection is the result of SelectProposerDetailed.
+type ProposerSelection struct {
+	Proposer *Validator
+	Height   int64
+	Round    int32
+	// Seed is the round hash (MakeRoundHash) the proposer was sampled with.
+	Seed []byte
+}
+
+// SelectProposerWithParams is the same as SelectProposer, but the proposer is
+// sampled as set by the ostracon consensus params of the chain. The consensus
+// uses it, as all the nodes must select the same proposer.
+func (vals *ValidatorSet) SelectProposerWithParams(
+	params OCConsensusParams,
+	proofHash []byte,
+	height int64,
+	round int32,
+) *Validator {
+	return vals.selectProposer(proofHash, height, round, params, nil).Proposer
+}
+
+// SelectProposerDetailed is the same as SelectProposer, but also returns the
+// inputs of the selection, e.g. to debug proposer changes across rounds.
+func (vals *ValidatorSet) SelectProposerDetailed(proofHash []byte, height int64, round int32) ProposerSelection {
+	return vals.selectProposer(proofHash, height, round, OCConsensusParams{}, nil)
+}
+
+// ProposerTieBreak decides the order in which validators of the same voting
+// power are sampled by the proposer selection.
+type ProposerTieBreak int32
+
+const (
+	// ProposerTieBreakByAddress samples validators of the same voting power
+	// in the order of their addresses, i.e. the order of the validator set.
+	ProposerTieBreakByAddress ProposerTieBreak = iota
+	// ProposerTieBreakByPubKeyHash samples validators of the same voting
+	// power in the order of the hashes of their public keys. Unlike an
+	// address, the hash can't be ground to take a favourable position.
+	ProposerTieBreakByPubKeyHash
+)
+
+// SelectProposerExcluding is the same as SelectProposer, but never selects the
+// validators of the given addresses, e.g. jailed validators which stay in the
+// set to verify their signatures. The proposer is sampled among the other
+// validators in proportion to their voting power. It panics if all the
+// validators are excluded.
+func (vals *ValidatorSet) SelectProposerExcluding(
+	proofHash []byte,
+	height int64,
+	round int32,
+	excluded [][]byte,
+) *Validator {
+	return vals.selectProposer(proofHash, height, round, OCConsensusParams{}, excluded).Proposer
+}
+
+// MaxProposerCycleLength caps the length of the sequence returned by
+// ProposerCycle, as a validator with a tiny share of the voting power may
+// take very long to be selected.
+const MaxProposerCycleLength = 10000
+
+// ProposerCycle returns the proposers of the given round at the heights from
+// startHeight on, until every validator of the set has been selected at least
+// once or MaxProposerCycleLength proposers have been selected. The same hash
+// is used for all the heights, so this is a projection of the schedule, e.g.
+// for liveness planning; the actual proposers depend on the proof hash of
+// every block. The receiver is not modified.
+func (vals *ValidatorSet) ProposerCycle(hash []byte, startHeight int64, round int32) []*Validator {
+	if vals.IsNilOrEmpty() {
+		return nil
+	}
+	seen := make(map[string]struct{}, vals.Size())
+	cycle := make([]*Validator, 0, vals.Size())
+	for height := startHeight; len(cycle) < MaxProposerCycleLength; height++ {
+		proposer := vals.SelectProposer(hash, height, round)
+		cycle = append(cycle, proposer.Copy())
+		seen[string(proposer.Address)] = struct{}{}
+		if len(seen) == vals.Size() {
+			break
+		}
+	}
+	return cycle
+}
+
+const (
+	// DefaultProposerSelectionPrecision is the default number of random bits
+	// the proposer is sampled with.
+	DefaultProposerSelectionPrecision = 63
+	// MaxProposerSelectionPrecision is the maximum number of random bits the
+	// proposer can be sampled with.
+	MaxProposerSelectionPrecision = 4 * 63
+)
+
+// ScheduleChange is a height at which the proposer differs between two
+// validator sets, as returned by ScheduleDiff.
+type ScheduleChange struct {
+	Height int64
+	Old    *Validator
+	New    *Validator
+}
+
+// ScheduleDiff returns the heights among the count heights from fromHeight on
+// at which oldSet and newSet select different proposers for the given hash and
+// round, e.g. to show the impact of a validator set change on the upcoming
+// proposers. As with ProposerCycle, the same hash is used for all the heights.
+func ScheduleDiff(
+	oldSet, newSet *ValidatorSet,
+	hash []byte,
+	fromHeight int64,
+	count int,
+	round int32,
+) []ScheduleChange {
+	var changes []ScheduleChange
+	for height := fromHeight; height < fromHeight+int64(count); height++ {
+		oldProposer := oldSet.SelectProposer(hash, height, round)
+		newProposer := newSet.SelectProposer(hash, height, round)
+		if !bytes.Equal(oldProposer.Address, newProposer.Address) {
+			changes = append(changes, ScheduleChange{
+				Height: height,
+				Old:    oldProposer.Copy(),
+				New:    newProposer.Copy(),
+			})
+		}
+	}
+	return changes
+}
+
+func (vals *ValidatorSet) selectProposer(
+	proofHash []byte,
+	height int64,
+	round int32,
+	params OCConsensusParams,
+	excluded [][]byte,
+) ProposerSelection {
 	if vals.IsNilOrEmpty() {
 		panic("empty validator set")
 	}
-	seed := hashToSeed(MakeRoundHash(proofHash, height, round))
-	random := nextRandom(&seed)
+	candidates := vals.Validators
+	switch params.ProposerTieBreak {
+	case ProposerTieBreakByAddress:
+	case ProposerTieBreakByPubKeyHash:
+		candidates = validatorsByPubKeyHash(candidates)
+	default:
+		panic(fmt.Sprintf("unknown proposer tie-break %d", params.ProposerTieBreak))
+	}
+
 	totalVotingPower := vals.TotalVotingPower()
+	if len(excluded) > 0 {
+		candidates, totalVotingPower = excludeValidators(candidates, excluded)
+		if len(candidates) == 0 {
+			panic("all validators are excluded from the proposer selection")
+		}
+	}
+
+	precision := params.proposerSelectionPrecision()
+
+	roundHash := MakeRoundHash(proofHash, height, round)
+	seed := hashToSeed(roundHash)
+	random := nextRandom(&seed)
 	thresholdVotingPower := dividePoint(random, totalVotingPower)
+	if precision > DefaultProposerSelectionPrecision {
+		thresholdVotingPower = dividePointPrecise(random, &seed, totalVotingPower, precision)
+	}
 	threshold := thresholdVotingPower
-	for _, val := range vals.Validators {
+	for _, val := range candidates {
 		if threshold < uint64(val.VotingPower) {
-			return val
+			return ProposerSelection{Proposer: val, Height: height, Round: round, Seed: roundHash}
 		}
 		threshold -= uint64(val.VotingPower)
 	}
@@ -822,6 +1685,72 @@ func (vals *ValidatorSet) SelectProposer(proofHash []byte, height int64, round i
 		random, thresholdVotingPower, totalVotingPower, vals))
 }
 
+// excludeValidators returns the validators of the list except those of the
+// given addresses, and their total voting power.
+func excludeValidators(vals []*Validator, excluded [][]byte) ([]*Validator, int64) {
+	excludedSet := make(map[string]struct{}, len(excluded))
+	for _, address := range excluded {
+		excludedSet[string(address)] = struct{}{}
+	}
+	candidates := make([]*Validator, 0, len(vals))
+	var total int64
+	for _, val := range vals {
+		if _, ok := excludedSet[string(val.Address)]; ok {
+			continue
+		}
+		candidates = append(candidates, val)
+		total += val.VotingPower
+	}
+	return candidates, total
+}
+
+// validatorsByPubKeyHash returns a copy of the list, sorted by voting power
+// (descending) like a validator set, but with the validators of the same voting
+// power sorted by the hash of their public key.
+func validatorsByPubKeyHash(vals []*Validator) []*Validator {
+	type keyedValidator struct {
+		val  *Validator
+		hash []byte
+	}
+	keyed := make([]keyedValidator, len(vals))
+	for i, val := range vals {
+		var pubKey []byte
+		if val.PubKey != nil {
+			pubKey = val.PubKey.Bytes()
+		}
+		keyed[i] = keyedValidator{val: val, hash: tmhash.Sum(pubKey)}
+	}
+	// stable, so that validators of the same key keep their address order
+	sort.SliceStable(keyed, func(i, j int) bool {
+		if keyed[i].val.VotingPower != keyed[j].val.VotingPower {
+			return keyed[i].val.VotingPower > keyed[j].val.VotingPower
+		}
+		return bytes.Compare(keyed[i].hash, keyed[j].hash) < 0
+	})
+	sorted := make([]*Validator, len(keyed))
+	for i := range keyed {
+		sorted[i] = keyed[i].val
+	}
+	return sorted
+}
+
+// ShuffleWithSeed returns copies of the validators in an order shuffled by the
+// given seed, e.g. to assign them to committees. The shuffle is a Fisher-Yates
+// driven by the random numbers of the proposer selection, so the same seed
+// yields the same order on every node.
+func (vals *ValidatorSet) ShuffleWithSeed(seed []byte) []*Validator {
+	if vals == nil {
+		return []*Validator{}
+	}
+	shuffled := validatorListCopy(vals.Validators)
+	random := hashToSeed(tmhash.Sum(seed))
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := dividePoint(nextRandom(&random), int64(i+1))
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	return shuffled
+}
+
 var divider *big.Int
 
 func init() {
@@ -839,6 +1768,20 @@ func dividePoint(x uint64, y int64) uint64 {
 	return a.Uint64()
 }
 
+// dividePointPrecise is the same as dividePoint, but x is extended with the
+// next random numbers of the seed up to the given number of bits, a multiple
+// of 63. It computes x÷2^precision×y.
+func dividePointPrecise(x uint64, seed *uint64, y int64, precision uint) uint64 {
+	a := new(big.Int).SetUint64(x & math.MaxInt64)
+	for bits := uint(63); bits < precision; bits += 63 {
+		a.Lsh(a, 63)
+		a.Or(a, new(big.Int).SetUint64(nextRandom(seed)&math.MaxInt64))
+	}
+	a.Mul(a, big.NewInt(y))
+	a.Rsh(a, precision)
+	return a.Uint64()
+}
+
 // nextRandom implements SplitMix64 (based on http://xoshiro.di.unimi.it/splitmix64.c)
 //
 // The PRNG used for this random selection:
@@ -940,6 +1883,10 @@ func (vals *ValidatorSet) ToProto() (*tmproto.ValidatorSet, error) {
 		return &tmproto.ValidatorSet{}, nil // validator set should never be nil
 	}
 
+	if err := vals.CheckPubKeys(); err != nil {
+		return nil, err
+	}
+
 	vp := new(tmproto.ValidatorSet)
 	valsProto := make([]*tmproto.Validator, len(vals.Validators))
 	for i := 0; i < len(vals.Validators); i++ {
@@ -956,6 +1903,24 @@ func (vals *ValidatorSet) ToProto() (*tmproto.ValidatorSet, error) {
 	return vp, nil
 }
 
+// CheckPubKeys returns an error reporting the index and address of the first
+// validator of the set lacking a pubkey of a supported type, e.g. to be called
+// before serializing the set.
+func (vals *ValidatorSet) CheckPubKeys() error {
+	for i, val := range vals.Validators {
+		if val == nil {
+			return fmt.Errorf("validator #%d is nil", i)
+		}
+		if val.PubKey == nil {
+			return fmt.Errorf("validator #%d (%v) has no pubkey", i, val.Address)
+		}
+		if _, err := cryptoenc.PubKeyToProto(val.PubKey); err != nil {
+			return fmt.Errorf("validator #%d (%v) has an invalid pubkey: %w", i, val.Address, err)
+		}
+	}
+	return nil
+}
+
 // ValidatorSetFromProto sets a protobuf ValidatorSet to the given pointer.
 // It returns an error if any of the validators from the set or the proposer
 // is invalid
@@ -1010,12 +1975,76 @@ func ValidatorSetFromExistingValidators(valz []*Validator) (*ValidatorSet, error
 //
 // EXPOSED FOR TESTING.
 func RandValidatorSet(numValidators int, votingPower int64) (*ValidatorSet, []PrivValidator) {
+	keyTypes := make([]string, numValidators)
+	for i := range keyTypes {
+		keyTypes[i] = ABCIPubKeyTypeEd25519
+	}
+	return RandValidatorSetWithKeyTypes(votingPower, keyTypes)
+}
+
+// RandValidatorSetWithKeyTypes returns a randomized validator set of a
+// validator of each given key type (see NewMockPVWithKeyType), where each
+// validator has a voting power of +votingPower+. The key types can be mixed.
+//
+// EXPOSED FOR TESTING.
+func RandValidatorSetWithKeyTypes(votingPower int64, keyTypes []string) (*ValidatorSet, []PrivValidator) {
+	var (
+		valz           = make([]*Validator, len(keyTypes))
+		privValidators = make([]PrivValidator, len(keyTypes))
+	)
+
+	for i, keyType := range keyTypes {
+		val, privValidator := RandValidatorWithKeyType(false, votingPower, keyType)
+		valz[i] = val
+		privValidators[i] = privValidator
+	}
+
+	vals := NewValidatorSet(valz)
+	sort.Sort(PrivValidatorsByAddress(privValidators))
+
+	return vals, privValidators
+}
+
+// PowerDistribution is the distribution of the voting powers of the validators
+// generated by RandValidatorSetDistribution.
+type PowerDistribution int
+
+const (
+	// PowerDistributionUniform draws the powers uniformly in [1, maxRandPower].
+	PowerDistributionUniform PowerDistribution = iota
+	// PowerDistributionZipf gives the validator of rank k (1-based) a power of
+	// maxRandPower/k: a few validators hold most of the power.
+	PowerDistributionZipf
+	// PowerDistributionExponential draws the powers from an exponential
+	// distribution of mean maxRandPower/8.
+	PowerDistributionExponential
+)
+
+// maxRandPower is the scale of the powers of RandValidatorSetDistribution.
+const maxRandPower = 1 << 20
+
+// RandValidatorSetDistribution returns a randomized validator set (size:
+// +numValidators+), where the voting powers follow the given distribution.
+//
+// EXPOSED FOR TESTING.
+func RandValidatorSetDistribution(numValidators int, dist PowerDistribution) (*ValidatorSet, []PrivValidator) {
 	var (
 		valz           = make([]*Validator, numValidators)
 		privValidators = make([]PrivValidator, numValidators)
 	)
 
 	for i := 0; i < numValidators; i++ {
+		var votingPower int64
+		switch dist {
+		case PowerDistributionUniform:
+			votingPower = 1 + tmrand.Int63n(maxRandPower)
+		case PowerDistributionZipf:
+			votingPower = maxRandPower / int64(i+1)
+		case PowerDistributionExponential:
+			votingPower = 1 + int64(-math.Log(1-tmrand.Float64())*maxRandPower/8)
+		default:
+			panic(fmt.Sprintf("unknown power distribution %d", dist))
+		}
 		val, privValidator := RandValidator(false, votingPower)
 		valz[i] = val
 		privValidators[i] = privValidator
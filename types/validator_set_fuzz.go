@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// validatorChangeSize is the size of a validator in the input of
+// DecodeValidatorSetChanges: a 1 byte id and an 8 bytes voting power.
+const validatorChangeSize = 1 + 8
+
+// DecodeValidatorSetChanges deterministically decodes raw bytes, e.g. produced
+// by a fuzzer, into the validators of a starting set and a list of changes to
+// apply to it.
+//
+// The first byte is the number n of starting validators. It is followed by
+// validators of 9 bytes each: an id, the address of the validator being
+// "v<id>", and a big-endian int64 voting power. The first n validators make up
+// the starting set and the others the changes. Trailing bytes not making up a
+// full validator are ignored.
+func DecodeValidatorSetChanges(data []byte) (start []*Validator, changes []*Validator, err error) {
+	if len(data) == 0 {
+		return nil, nil, errors.New("empty input")
+	}
+	n := int(data[0])
+	data = data[1:]
+
+	vals := make([]*Validator, 0, len(data)/validatorChangeSize)
+	for ; len(data) >= validatorChangeSize; data = data[validatorChangeSize:] {
+		vals = append(vals, &Validator{
+			Address:     []byte(fmt.Sprintf("v%d", data[0])),
+			VotingPower: int64(binary.BigEndian.Uint64(data[1:validatorChangeSize])),
+		})
+	}
+	if len(vals) < n {
+		return nil, nil, fmt.Errorf("expected %d starting validators, got %d", n, len(vals))
+	}
+	return vals[:n], vals[n:], nil
+}
+
+// FuzzUpdateWithChangeSet decodes data with DecodeValidatorSetChanges, creates
+// the starting set and applies the changes to it with UpdateWithChangeSet. Any
+// invalid input, e.g. duplicate validators or voting powers overflowing, is
+// reported as an error: it never panics. It is meant to be driven by go-fuzz or
+// a testing.F fuzz test.
+func FuzzUpdateWithChangeSet(data []byte) error {
+	start, changes, err := DecodeValidatorSetChanges(data)
+	if err != nil {
+		return err
+	}
+
+	// NewValidatorSet panics on an invalid set
+	vals := &ValidatorSet{}
+	if err := vals.updateWithChangeSet(start, false, 0); err != nil {
+		return fmt.Errorf("invalid starting set: %w", err)
+	}
+	return vals.UpdateWithChangeSet(changes)
+}
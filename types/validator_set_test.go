@@ -2,10 +2,12 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/big"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"testing/quick"
@@ -13,10 +15,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	"github.com/line/ostracon/crypto"
 	"github.com/line/ostracon/crypto/ed25519"
+	"github.com/line/ostracon/crypto/merkle"
+	"github.com/line/ostracon/crypto/secp256k1"
+	"github.com/line/ostracon/crypto/tmhash"
 	tmmath "github.com/line/ostracon/libs/math"
 	tmrand "github.com/line/ostracon/libs/rand"
 )
@@ -187,9 +193,13 @@ func bytesToInt(b []byte) int {
 }
 
 func verifyWinningRate(t *testing.T, vals *ValidatorSet, tries int, error float64) {
+	verifyWinningRateWithParams(t, vals, OCConsensusParams{}, tries, error)
+}
+
+func verifyWinningRateWithParams(t *testing.T, vals *ValidatorSet, params OCConsensusParams, tries int, error float64) {
 	selected := make([]int, len(vals.Validators))
 	for i := 0; i < tries; i++ {
-		prop := vals.SelectProposer([]byte{}, int64(i), 0)
+		prop := vals.SelectProposerWithParams(params, []byte{}, int64(i), 0)
 		for j := 0; j < len(vals.Validators); j++ {
 			if bytes.Equal(prop.Address, vals.Validators[j].Address) {
 				selected[j]++
@@ -205,6 +215,446 @@ func verifyWinningRate(t *testing.T, vals *ValidatorSet, tries int, error float6
 	}
 }
 
+func TestSelectProposerDetailed(t *testing.T) {
+	vset := NewValidatorSet([]*Validator{
+		newValidator([]byte("foo"), 1000),
+		newValidator([]byte("bar"), 1000),
+		newValidator([]byte("baz"), 1000),
+	})
+	proofHash := []byte("proof hash")
+	height := int64(10)
+
+	proposers := map[string]bool{}
+	for round := int32(0); round < 20; round++ {
+		selection := vset.SelectProposerDetailed(proofHash, height, round)
+		assert.Equal(t, height, selection.Height)
+		assert.Equal(t, round, selection.Round)
+		assert.Equal(t, MakeRoundHash(proofHash, height, round), selection.Seed)
+		assert.Equal(t, vset.SelectProposer(proofHash, height, round), selection.Proposer)
+		proposers[string(selection.Proposer.Address)] = true
+	}
+	// the round changes the proposer of a height
+	assert.Greater(t, len(proposers), 1)
+}
+
+func TestSelectProposerTieBreak(t *testing.T) {
+	// equal power validators whose addresses are in the reverse order of
+	// their pubkey hashes
+	pubKeys := []crypto.PubKey{
+		ed25519.GenPrivKeyFromSecret([]byte("a")).PubKey(),
+		ed25519.GenPrivKeyFromSecret([]byte("b")).PubKey(),
+		ed25519.GenPrivKeyFromSecret([]byte("c")).PubKey(),
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(tmhash.Sum(pubKeys[i].Bytes()), tmhash.Sum(pubKeys[j].Bytes())) < 0
+	})
+	valList := make([]*Validator, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		valList[i] = &Validator{
+			Address:     []byte{byte(len(pubKeys) - i)},
+			PubKey:      pubKey,
+			VotingPower: 100,
+		}
+	}
+	vals := NewValidatorSet(valList)
+
+	byAddressParams := OCConsensusParams{ProposerTieBreak: ProposerTieBreakByAddress}
+	byPubKeyHashParams := OCConsensusParams{ProposerTieBreak: ProposerTieBreakByPubKeyHash}
+	changed := false
+	for height := int64(0); height < 30; height++ {
+		byAddress := vals.SelectProposerWithParams(byAddressParams, []byte{}, height, 0)
+		byPubKeyHash := vals.SelectProposerWithParams(byPubKeyHashParams, []byte{}, height, 0)
+		// the default is by address
+		assert.Equal(t, vals.SelectProposer([]byte{}, height, 0), byAddress)
+		// the same sample is taken in the reversed order
+		idx, _ := vals.GetByAddress(byAddress.Address)
+		assert.Equal(t, vals.Validators[len(valList)-1-int(idx)], byPubKeyHash, height)
+		changed = changed || !bytes.Equal(byAddress.Address, byPubKeyHash.Address)
+	}
+	assert.True(t, changed)
+
+	unknown := OCConsensusParams{ProposerTieBreak: ProposerTieBreak(-1)}
+	assert.Error(t, ValidateOCConsensusParams(unknown))
+	assert.Panics(t, func() {
+		vals.SelectProposerWithParams(unknown, []byte{}, 0, 0)
+	})
+}
+
+func TestValidatorSetProposerCycle(t *testing.T) {
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("foo"), 1000),
+		newValidator([]byte("bar"), 300),
+		newValidator([]byte("baz"), 330),
+		newValidator([]byte("qux"), 10),
+	})
+	original := vals.Copy()
+
+	hash := []byte("proof hash")
+	cycle := vals.ProposerCycle(hash, 5, 1)
+	require.NotEmpty(t, cycle)
+	assert.Less(t, len(cycle), MaxProposerCycleLength)
+
+	// every validator has proposed, the last one for the first time
+	seen := make(map[string]int)
+	for i, proposer := range cycle {
+		assert.Equal(t, vals.SelectProposer(hash, 5+int64(i), 1), proposer)
+		seen[string(proposer.Address)]++
+	}
+	for _, val := range vals.Validators {
+		assert.Contains(t, seen, string(val.Address))
+	}
+	assert.Equal(t, 1, seen[string(cycle[len(cycle)-1].Address)])
+
+	// the receiver is not modified
+	assert.Equal(t, original, vals)
+
+	assert.Nil(t, (&ValidatorSet{}).ProposerCycle(hash, 1, 0))
+}
+
+func TestValidatorSetExportImportPriorities(t *testing.T) {
+	vset := randValidatorSet(5)
+	vset.IncrementProposerPriority(7)
+	priorities := vset.ExportPriorities()
+	require.Len(t, priorities, 5)
+
+	// a restarted set has its priorities reset
+	restored := vset.Copy()
+	for _, val := range restored.Validators {
+		val.ProposerPriority = 0
+	}
+	require.NoError(t, restored.ImportPriorities(priorities))
+	assert.Equal(t, vset, restored)
+	assert.Equal(t, priorities, restored.ExportPriorities())
+
+	// the priorities are preserved by the serialization
+	assert.Equal(t, priorities, vset.fromBytes(vset.toBytes()).ExportPriorities())
+
+	// the addresses must match
+	before := restored.Copy()
+	delete(priorities, vset.Validators[0].Address.String())
+	assert.Error(t, restored.ImportPriorities(priorities))
+	priorities[Address("unknown").String()] = 1
+	assert.Error(t, restored.ImportPriorities(priorities))
+	assert.Equal(t, before, restored)
+
+	// a frozen set can't be changed
+	frozen := vset.Copy().Freeze()
+	assert.PanicsWithValue(t, "cannot mutate a frozen validator set", func() {
+		_ = frozen.ImportPriorities(vset.ExportPriorities())
+	})
+}
+
+func TestMergeChangeSets(t *testing.T) {
+	vset := NewValidatorSet([]*Validator{
+		newValidator([]byte("v1"), 10),
+		newValidator([]byte("v2"), 20),
+		newValidator([]byte("v3"), 30),
+	})
+	a := []*Validator{newValidator([]byte("v2"), 25), newValidator([]byte("v1"), 15)}
+	b := []*Validator{newValidator([]byte("v3"), 0), newValidator([]byte("v2"), 35)}
+
+	merged, err := MergeChangeSets(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, []*Validator{
+		newValidator([]byte("v1"), 15),
+		newValidator([]byte("v2"), 35),
+		newValidator([]byte("v3"), 0),
+	}, merged)
+	// the change sets aren't modified
+	assert.EqualValues(t, 25, a[0].VotingPower)
+
+	require.NoError(t, vset.UpdateWithChangeSet(merged))
+	assert.Equal(t, []testVal{{"v2", 35}, {"v1", 15}}, toTestValList(vset.Validators))
+
+	_, err = MergeChangeSets(append(a, newValidator([]byte("v1"), 1)), b)
+	assert.Error(t, err)
+	_, err = MergeChangeSets(a, append(b, newValidator([]byte("v3"), 1)))
+	assert.Error(t, err)
+}
+
+func TestValidatorSetWeightedMedian(t *testing.T) {
+	vset := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 10),
+		newValidator([]byte("b"), 20),
+		newValidator([]byte("c"), 30),
+		newValidator([]byte("d"), 40),
+	})
+	values := func(a, b, c, d int64) map[string]int64 {
+		return map[string]int64{
+			Address("a").String(): a,
+			Address("b").String(): b,
+			Address("c").String(): c,
+			Address("d").String(): d,
+		}
+	}
+
+	testCases := []struct {
+		values map[string]int64
+		median int64
+	}{
+		// 10+20+30 >= 100/2
+		{values(1, 2, 3, 4), 3},
+		// 40+10 >= 100/2
+		{values(2, 3, 4, 1), 2},
+		// the most powerful validator doesn't make the median alone
+		{values(5, 5, 5, 100), 5},
+		{values(-7, 0, 7, 7), 7},
+		{values(3, 3, 3, 3), 3},
+	}
+	for i, tc := range testCases {
+		median, err := vset.WeightedMedian(tc.values)
+		require.NoError(t, err, i)
+		assert.Equal(t, tc.median, median, i)
+	}
+
+	// a validator with the majority of the voting power makes the median
+	vset = NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 1),
+		newValidator([]byte("b"), 1),
+		newValidator([]byte("c"), 5),
+	})
+	values3 := map[string]int64{
+		Address("a").String(): 100,
+		Address("b").String(): 200,
+		Address("c").String(): 42,
+		Address("x").String(): 1,
+	}
+	median, err := vset.WeightedMedian(values3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, median)
+
+	delete(values3, Address("b").String())
+	_, err = vset.WeightedMedian(values3)
+	assert.Error(t, err)
+	_, err = NewValidatorSet(nil).WeightedMedian(values3)
+	assert.Error(t, err)
+}
+
+func TestCumulativePowerIndexReachesQuorum(t *testing.T) {
+	// the decisions of the index match tallying the voting power linearly
+	linearQuorum := func(vals *ValidatorSet, addrs [][]byte) bool {
+		var power int64
+		seen := make(map[string]bool)
+		for _, addr := range addrs {
+			if seen[string(addr)] {
+				continue
+			}
+			seen[string(addr)] = true
+			if _, val := vals.GetByAddress(addr); val != nil {
+				power += val.VotingPower
+			}
+		}
+		return power > 0 && vals.IsSuperMajorityFor(power)
+	}
+
+	for i := 0; i < 50; i++ {
+		vals := randValidatorSet(1 + tmrand.Intn(20))
+		idx := vals.CumulativePowerIndex()
+		for j := 0; j < 50; j++ {
+			var addrs [][]byte
+			for _, val := range vals.Validators {
+				switch tmrand.Intn(4) {
+				case 0:
+				case 1:
+					addrs = append(addrs, val.Address, val.Address)
+				default:
+					addrs = append(addrs, val.Address)
+				}
+			}
+			if tmrand.Intn(2) == 0 {
+				addrs = append(addrs, tmrand.Bytes(crypto.AddressSize))
+			}
+			assert.Equal(t, linearQuorum(vals, addrs), idx.ReachesQuorum(addrs), "addrs %X", addrs)
+		}
+	}
+
+	// 2/3 exactly is not a quorum
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 2),
+		newValidator([]byte("b"), 2),
+		newValidator([]byte("c"), 2),
+	})
+	idx := vals.CumulativePowerIndex()
+	assert.False(t, idx.ReachesQuorum([][]byte{[]byte("a"), []byte("b")}))
+	assert.False(t, idx.ReachesQuorum([][]byte{[]byte("a"), []byte("b"), []byte("a")}))
+	assert.True(t, idx.ReachesQuorum([][]byte{[]byte("a"), []byte("b"), []byte("c")}))
+	assert.False(t, idx.ReachesQuorum(nil))
+	assert.False(t, NewValidatorSet(nil).CumulativePowerIndex().ReachesQuorum([][]byte{[]byte("a")}))
+}
+
+func TestValidatorSetHashWithVersion(t *testing.T) {
+	valList := make([]*Validator, 5)
+	for i := range valList {
+		pubKey := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("validator %d", i))).PubKey()
+		valList[i] = NewValidator(pubKey, int64(10*(i+1)))
+	}
+	vset := NewValidatorSet(valList)
+
+	// golden vectors: a change of these hashes breaks the existing chains
+	golden := map[int]string{
+		ValidatorSetHashV1: "50131DAD8884A6874A92EE522BFBC263B2A9E1AD2EBE1239A0DB07EA3A5D8587",
+	}
+	for v, hash := range golden {
+		assert.Equal(t, hash, fmt.Sprintf("%X", vset.HashWithVersion(v)), "version %d", v)
+	}
+
+	assert.Equal(t, vset.HashWithVersion(LatestValidatorSetHashVersion), vset.Hash())
+	assert.Nil(t, vset.HashWithVersion(0))
+	assert.Nil(t, vset.HashWithVersion(LatestValidatorSetHashVersion+1))
+}
+
+func TestValidatorSetDiff(t *testing.T) {
+	oldSet := NewValidatorSet([]*Validator{
+		newValidator([]byte("foo"), 100),
+		newValidator([]byte("bar"), 200),
+		newValidator([]byte("baz"), 300),
+	})
+	newSet := NewValidatorSet([]*Validator{
+		newValidator([]byte("foo"), 100),
+		newValidator([]byte("bar"), 250),
+		newValidator([]byte("qux"), 400),
+	})
+
+	diff := oldSet.Diff(newSet)
+	assert.False(t, diff.IsEmpty())
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, Address("qux"), diff.Added[0].Address)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, Address("baz"), diff.Removed[0].Address)
+	require.Len(t, diff.Updated, 1)
+	assert.Equal(t, Address("bar"), diff.Updated[0].Address)
+	assert.EqualValues(t, 250, diff.Updated[0].VotingPower)
+
+	// the reverse diff swaps the added and removed validators
+	reverse := newSet.Diff(oldSet)
+	assert.Equal(t, diff.Added, reverse.Removed)
+	assert.Equal(t, diff.Removed, reverse.Added)
+
+	// the proposer priorities are ignored
+	incremented := oldSet.CopyIncrementProposerPriority(3)
+	assert.True(t, oldSet.Diff(incremented).IsEmpty())
+}
+
+func TestScheduleDiff(t *testing.T) {
+	valList := []*Validator{
+		newValidator([]byte("foo"), 1000),
+		newValidator([]byte("bar"), 300),
+		newValidator([]byte("baz"), 330),
+	}
+	oldSet := NewValidatorSet(valList)
+	hash := []byte("proof hash")
+
+	assert.Empty(t, ScheduleDiff(oldSet, oldSet.Copy(), hash, 1, 100, 0))
+
+	// removing the high power validator changes the proposers it was selected at
+	newSet := oldSet.Copy()
+	require.NoError(t, newSet.UpdateWithChangeSet([]*Validator{newValidator([]byte("foo"), 0)}))
+
+	changes := ScheduleDiff(oldSet, newSet, hash, 1, 100, 0)
+	assert.Greater(t, len(changes), 1)
+	changed := make(map[int64]bool, len(changes))
+	for _, change := range changes {
+		assert.Equal(t, oldSet.SelectProposer(hash, change.Height, 0), change.Old)
+		assert.Equal(t, newSet.SelectProposer(hash, change.Height, 0), change.New)
+		assert.NotEqual(t, change.Old.Address, change.New.Address)
+		changed[change.Height] = true
+	}
+	// every height "foo" was selected at has changed
+	for height := int64(1); height <= 100; height++ {
+		if bytes.Equal(oldSet.SelectProposer(hash, height, 0).Address, []byte("foo")) {
+			assert.True(t, changed[height], height)
+		}
+	}
+}
+
+func TestSelectProposerExcluding(t *testing.T) {
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("foo"), 1000),
+		newValidator([]byte("bar"), 300),
+		newValidator([]byte("baz"), 330),
+	})
+	jailed := [][]byte{[]byte("foo")}
+
+	selected := make(map[string]int)
+	for height := int64(0); height < 1000; height++ {
+		proposer := vals.SelectProposerExcluding([]byte{}, height, 0, jailed)
+		assert.NotEqual(t, []byte("foo"), []byte(proposer.Address))
+		selected[string(proposer.Address)]++
+
+		// the same as SelectProposer without any exclusion
+		assert.Equal(t, vals.SelectProposer([]byte{}, height, 0),
+			vals.SelectProposerExcluding([]byte{}, height, 0, nil))
+	}
+	// the others are selected in proportion to their voting power
+	assert.InDelta(t, 300.0/630, float64(selected["bar"])/1000, 0.05)
+	assert.InDelta(t, 330.0/630, float64(selected["baz"])/1000, 0.05)
+
+	// the jailed validator is still in the set
+	assert.Equal(t, 3, vals.Size())
+	assert.Equal(t, int64(1630), vals.TotalVotingPower())
+
+	assert.Panics(t, func() {
+		vals.SelectProposerExcluding([]byte{}, 0, 0, [][]byte{[]byte("foo"), []byte("bar"), []byte("baz")})
+	})
+}
+
+func TestValidatorSetShuffleWithSeed(t *testing.T) {
+	vals := randValidatorSet(30)
+	addresses := func(list []*Validator) []string {
+		addrs := make([]string, len(list))
+		for i, val := range list {
+			addrs[i] = val.Address.String()
+		}
+		return addrs
+	}
+
+	shuffled := vals.ShuffleWithSeed([]byte("seed"))
+	// the same seed yields the same order, also on another copy of the set
+	assert.Equal(t, addresses(shuffled), addresses(vals.Copy().ShuffleWithSeed([]byte("seed"))))
+	// a permutation of the set
+	assert.ElementsMatch(t, addresses(vals.Validators), addresses(shuffled))
+	assert.NotEqual(t, addresses(vals.Validators), addresses(shuffled))
+	// different seeds differ
+	assert.NotEqual(t, addresses(shuffled), addresses(vals.ShuffleWithSeed([]byte("another seed"))))
+
+	// the set is left unchanged
+	shuffled[0].VotingPower++
+	_, val := vals.GetByAddress(shuffled[0].Address)
+	assert.Equal(t, val.VotingPower+1, shuffled[0].VotingPower)
+
+	assert.Empty(t, NewValidatorSet(nil).ShuffleWithSeed([]byte("seed")))
+}
+
+func TestValidatorSetIsSuperMajorityFor(t *testing.T) {
+	// the lowest super majority of the given total voting power, computed
+	// with big integers
+	threshold := func(total int64) int64 {
+		twoThirds := new(big.Int).Div(new(big.Int).Mul(big.NewInt(total), big.NewInt(2)), big.NewInt(3))
+		return twoThirds.Int64() + 1
+	}
+	testCases := []struct {
+		total     int64
+		threshold int64
+	}{
+		{1, 1},
+		{2, 2},
+		{3, 3},
+		{4, 3},
+		{30, 21},
+		{100, 67},
+		{MaxTotalVotingPower - 2, threshold(MaxTotalVotingPower - 2)},
+		{MaxTotalVotingPower - 1, threshold(MaxTotalVotingPower - 1)},
+		{MaxTotalVotingPower, threshold(MaxTotalVotingPower)},
+	}
+	for _, tc := range testCases {
+		vals := NewValidatorSet([]*Validator{newValidator([]byte("foo"), tc.total)})
+		assert.Equal(t, threshold(tc.total), tc.threshold, tc.total)
+		assert.False(t, vals.IsSuperMajorityFor(tc.threshold-1), tc.total)
+		assert.True(t, vals.IsSuperMajorityFor(tc.threshold), tc.total)
+		assert.True(t, vals.IsSuperMajorityFor(tc.threshold+1), tc.total)
+	}
+}
+
 func TestProposerSelection1(t *testing.T) {
 	vset := NewValidatorSet([]*Validator{
 		newValidator([]byte("foo"), 1000),
@@ -325,6 +775,40 @@ func TestProposerSelection3(t *testing.T) {
 	}
 }
 
+func TestValidatorSetCheckPriorityInvariants(t *testing.T) {
+	vset := NewValidatorSet([]*Validator{
+		newValidator([]byte("avalidator_address12"), 1),
+		newValidator([]byte("bvalidator_address12"), 2),
+		newValidator([]byte("cvalidator_address12"), 3),
+	})
+	for _, val := range vset.Validators {
+		val.PubKey = ed25519.GenPrivKey().PubKey()
+	}
+	for i := 0; i < 10; i++ {
+		vset.IncrementProposerPriority(1)
+		vset = vset.fromBytes(vset.toBytes())
+		require.NoError(t, vset.CheckPriorityInvariants())
+	}
+
+	// not centered
+	corrupted := vset.fromBytes(vset.toBytes())
+	corrupted.Validators[0].ProposerPriority += 3
+	err := corrupted.CheckPriorityInvariants()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not centered")
+
+	// centered, but exceeding the window of 2*6
+	corrupted = vset.fromBytes(vset.toBytes())
+	corrupted.Validators[0].ProposerPriority = 7
+	corrupted.Validators[1].ProposerPriority = -7
+	corrupted.Validators[2].ProposerPriority = 0
+	err = corrupted.CheckPriorityInvariants()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceed the window")
+
+	assert.NoError(t, NewValidatorSet(nil).CheckPriorityInvariants())
+}
+
 func newValidator(address []byte, power int64) *Validator {
 	return &Validator{Address: address, VotingPower: power}
 }
@@ -414,6 +898,235 @@ func TestValidatorSetTotalVotingPowerPanicsOnOverflow(t *testing.T) {
 	assert.Panics(t, shouldPanic)
 }
 
+func TestValidatorSetTryTotalVotingPower(t *testing.T) {
+	vals := &ValidatorSet{Validators: []*Validator{
+		{Address: []byte("a"), VotingPower: math.MaxInt64, ProposerPriority: 0},
+		{Address: []byte("b"), VotingPower: math.MaxInt64, ProposerPriority: 0},
+		{Address: []byte("c"), VotingPower: math.MaxInt64, ProposerPriority: 0},
+	}}
+
+	_, err := vals.TryTotalVotingPower()
+	assert.Equal(t, ErrTotalVotingPowerOverflow, err)
+	assert.Panics(t, func() { vals.TotalVotingPower() })
+
+	vals = NewValidatorSet([]*Validator{newValidator([]byte("a"), 1), newValidator([]byte("b"), 2)})
+	total, err := vals.TryTotalVotingPower()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, total)
+	assert.Equal(t, vals.TotalVotingPower(), total)
+}
+
+func TestRandValidatorSetDistribution(t *testing.T) {
+	const n = 20
+
+	vals, privVals := RandValidatorSetDistribution(n, PowerDistributionZipf)
+	require.Equal(t, n, vals.Size())
+	require.Len(t, privVals, n)
+	for i, val := range vals.Validators {
+		assert.EqualValues(t, maxRandPower/(i+1), val.VotingPower, i)
+		if i > 0 {
+			assert.Less(t, val.VotingPower, vals.Validators[i-1].VotingPower, i)
+		}
+	}
+	// the top validator outweighs the bottom half
+	var bottomHalf int64
+	for _, val := range vals.Validators[n/2:] {
+		bottomHalf += val.VotingPower
+	}
+	assert.Greater(t, vals.Validators[0].VotingPower, bottomHalf)
+
+	for _, dist := range []PowerDistribution{PowerDistributionUniform, PowerDistributionExponential} {
+		vals, privVals := RandValidatorSetDistribution(n, dist)
+		require.Equal(t, n, vals.Size())
+		require.Len(t, privVals, n)
+		for _, val := range vals.Validators {
+			assert.Positive(t, val.VotingPower)
+		}
+	}
+
+	assert.Panics(t, func() { RandValidatorSetDistribution(n, PowerDistribution(-1)) })
+}
+
+func TestValidatorSetMatchesCommitValidators(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+	voteSet, vals, privVals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, privVals, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, vals.VerifyCommit(chainID, blockID, h, commit))
+
+	assert.True(t, vals.MatchesCommitValidators(commit))
+	assert.False(t, vals.MatchesCommitValidators(nil))
+
+	// absent signatures don't need an address
+	partial := *commit
+	partial.Signatures = append([]CommitSig{}, commit.Signatures...)
+	partial.Signatures[1] = NewCommitSigAbsent()
+	assert.True(t, vals.MatchesCommitValidators(&partial))
+
+	// a commit sized for another set
+	otherVoteSet, otherVals, otherPrivVals := randVoteSet(h, 0, tmproto.PrecommitType, 5, 10)
+	otherCommit, err := MakeCommit(blockID, h, 0, otherVoteSet, otherPrivVals, time.Now())
+	require.NoError(t, err)
+	assert.False(t, vals.MatchesCommitValidators(otherCommit))
+	assert.False(t, otherVals.MatchesCommitValidators(commit))
+
+	// a commit of the same size signed by other validators
+	otherVoteSet, _, otherPrivVals = randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	otherCommit, err = MakeCommit(blockID, h, 0, otherVoteSet, otherPrivVals, time.Now())
+	require.NoError(t, err)
+	assert.False(t, vals.MatchesCommitValidators(otherCommit))
+}
+
+func TestValidatorSetSignedPower(t *testing.T) {
+	var (
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+	voteSet, vals, privVals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, privVals, time.Now())
+	require.NoError(t, err)
+
+	signed, total, err := vals.SignedPower(commit)
+	require.NoError(t, err)
+	assert.EqualValues(t, 40, signed)
+	assert.EqualValues(t, 40, total)
+
+	// an absent validator and a vote for nil don't count
+	partial := *commit
+	partial.Signatures = append([]CommitSig{}, commit.Signatures...)
+	partial.Signatures[1] = NewCommitSigAbsent()
+	partial.Signatures[2].BlockIDFlag = BlockIDFlagNil
+	signed, total, err = vals.SignedPower(&partial)
+	require.NoError(t, err)
+	assert.EqualValues(t, 20, signed)
+	assert.EqualValues(t, 40, total)
+
+	// a commit of other validators
+	otherVoteSet, _, otherPrivVals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	otherCommit, err := MakeCommit(blockID, h, 0, otherVoteSet, otherPrivVals, time.Now())
+	require.NoError(t, err)
+	_, _, err = vals.SignedPower(otherCommit)
+	assert.Error(t, err)
+	_, _, err = vals.SignedPower(nil)
+	assert.Error(t, err)
+}
+
+func TestChangeSetBetween(t *testing.T) {
+	var (
+		pubKeyA = ed25519.GenPrivKey().PubKey()
+		pubKeyB = ed25519.GenPrivKey().PubKey()
+		pubKeyC = ed25519.GenPrivKey().PubKey()
+		pubKeyD = ed25519.GenPrivKey().PubKey()
+	)
+	from := NewValidatorSet([]*Validator{
+		NewValidator(pubKeyA, 10),
+		NewValidator(pubKeyB, 20),
+		NewValidator(pubKeyC, 30),
+	})
+	to := NewValidatorSet([]*Validator{
+		NewValidator(pubKeyA, 10), // unchanged
+		NewValidator(pubKeyB, 25), // updated
+		NewValidator(pubKeyD, 40), // added
+	})
+
+	changes := ChangeSetBetween(from, to)
+	require.Len(t, changes, 3)
+	for i, change := range changes {
+		if i > 0 {
+			assert.Equal(t, -1, bytes.Compare(changes[i-1].Address, change.Address))
+		}
+		switch {
+		case bytes.Equal(change.Address, pubKeyB.Address()):
+			assert.EqualValues(t, 25, change.VotingPower)
+		case bytes.Equal(change.Address, pubKeyC.Address()):
+			assert.EqualValues(t, 0, change.VotingPower)
+		case bytes.Equal(change.Address, pubKeyD.Address()):
+			assert.EqualValues(t, 40, change.VotingPower)
+		default:
+			t.Errorf("unexpected change %v", change)
+		}
+	}
+
+	updated := from.Copy()
+	require.NoError(t, updated.UpdateWithChangeSet(changes))
+	assert.Equal(t, to.Hash(), updated.Hash())
+
+	// and back
+	require.NoError(t, updated.UpdateWithChangeSet(ChangeSetBetween(to, from)))
+	assert.Equal(t, from.Hash(), updated.Hash())
+
+	// no changes between equal sets
+	assert.Empty(t, ChangeSetBetween(from, from.Copy()))
+}
+
+func TestValidatorSetSelectionProbabilities(t *testing.T) {
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 10),
+		newValidator([]byte("b"), 30),
+		newValidator([]byte("c"), 60),
+	})
+	probabilities := vals.SelectionProbabilities()
+	assert.Equal(t, vals.PowerShare(), probabilities)
+
+	const tries = 100000
+	selected := make(map[string]int, vals.Size())
+	for i := 0; i < tries; i++ {
+		selected[vals.SelectProposer([]byte{}, int64(i), 0).Address.String()]++
+	}
+	for _, val := range vals.Validators {
+		addr := val.Address.String()
+		assert.InDelta(t, float64(val.VotingPower)/100, probabilities[addr], 1e-12, addr)
+		assert.InDelta(t, probabilities[addr], float64(selected[addr])/tries, 0.01, addr)
+	}
+}
+
+func TestValidatorSetPowerShare(t *testing.T) {
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 1),
+		newValidator([]byte("b"), 2),
+		newValidator([]byte("c"), 97),
+	})
+	shares := vals.PowerShare()
+	require.Len(t, shares, 3)
+	sum := 0.0
+	for _, val := range vals.Validators {
+		assert.InDelta(t, float64(val.VotingPower)/100, shares[val.Address.String()], 1e-12)
+		sum += shares[val.Address.String()]
+	}
+	assert.InDelta(t, 1.0, sum, 1e-9)
+
+	single := NewValidatorSet([]*Validator{newValidator([]byte("a"), 10)})
+	assert.Equal(t, map[string]float64{Address("a").String(): 1}, single.PowerShare())
+
+	assert.Empty(t, NewValidatorSet(nil).PowerShare())
+}
+
+func TestValidatorSetByzantinePower(t *testing.T) {
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 1),
+		newValidator([]byte("b"), 2),
+		newValidator([]byte("c"), 4),
+	})
+
+	power, err := vals.ByzantinePower([][]byte{[]byte("a"), []byte("c")})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 5, power)
+
+	power, err = vals.ByzantinePower(nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, power)
+
+	_, err = vals.ByzantinePower([][]byte{[]byte("a"), []byte("d")})
+	assert.Error(t, err)
+
+	_, err = vals.ByzantinePower([][]byte{[]byte("b"), []byte("b")})
+	assert.Error(t, err)
+}
+
 func TestAvgProposerPriority(t *testing.T) {
 	// Create Validator set without calling IncrementProposerPriority:
 	tcs := []struct {
@@ -747,6 +1460,140 @@ func TestValidatorSet_VerifyCommit_CheckAllSignatures(t *testing.T) {
 	}
 }
 
+func TestValidatorSet_VerifyCommit_DuplicateValidator(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	// the signature of the 1st validator is repeated in place of the 2nd one
+	commit.Signatures[1] = commit.Signatures[0]
+
+	err = commit.ValidateBasic()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "CommitSig #1 duplicates the validator")
+	}
+	for name, verify := range map[string]func() error{
+		"VerifyCommit": func() error {
+			return valSet.VerifyCommit(chainID, blockID, h, commit)
+		},
+		"VerifyCommitLight": func() error {
+			return valSet.VerifyCommitLight(chainID, blockID, h, commit)
+		},
+		"VerifyCommitParallel": func() error {
+			return valSet.VerifyCommitParallel(chainID, blockID, h, commit, 2)
+		},
+	} {
+		err := verify()
+		if assert.Error(t, err, name) {
+			assert.Contains(t, err.Error(), "wrong validator address (#1)", name)
+		}
+	}
+	err = valSet.VerifyCommitLightTrusting(chainID, commit, tmmath.Fraction{Numerator: 1, Denominator: 3})
+	assert.Error(t, err)
+}
+
+func TestValidatorSet_VerifyCommitParallel(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 100, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	malleate := func(f func(c *Commit)) *Commit {
+		c := *commit
+		c.Signatures = append([]CommitSig(nil), commit.Signatures...)
+		f(&c)
+		return &c
+	}
+	testCases := []struct {
+		name   string
+		commit *Commit
+	}{
+		{"valid", commit},
+		{"wrong signatures", malleate(func(c *Commit) {
+			c.Signatures[70].Signature = commit.Signatures[71].Signature
+			c.Signatures[40].Signature = commit.Signatures[41].Signature
+		})},
+		{"not enough voting power", malleate(func(c *Commit) {
+			for i := 0; i < 34; i++ {
+				c.Signatures[i*2] = NewCommitSigAbsent()
+			}
+		})},
+		{"wrong size", malleate(func(c *Commit) { c.Signatures = c.Signatures[1:] })},
+		{"wrong height", malleate(func(c *Commit) { c.Height++ })},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			expected := valSet.VerifyCommit(chainID, blockID, h, tc.commit)
+			for _, workers := range []int{0, 1, 7, 200} {
+				assert.Equal(t, expected, valSet.VerifyCommitParallel(chainID, blockID, h, tc.commit, workers),
+					"workers=%d", workers)
+			}
+		})
+	}
+}
+
+func TestValidatorSet_VerifyCommitWithTimestampTolerance(t *testing.T) {
+	var (
+		chainID   = "test_chain_id"
+		h         = int64(3)
+		blockID   = makeBlockIDRandom()
+		blockTime = time.Now()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, blockTime.Add(time.Second))
+	require.NoError(t, err)
+	assert.NoError(t, valSet.VerifyCommitWithTimestampTolerance(chainID, blockID, h, commit, blockTime, time.Minute))
+
+	// a commit signed with far-future timestamps
+	voteSet = NewVoteSet(chainID, h, 0, tmproto.PrecommitType, valSet)
+	futureCommit, err := MakeCommit(blockID, h, 0, voteSet, vals, blockTime.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.NoError(t, valSet.VerifyCommit(chainID, blockID, h, futureCommit))
+
+	// is only rejected under a strict tolerance
+	err = valSet.VerifyCommitWithTimestampTolerance(chainID, blockID, h, futureCommit, blockTime, time.Minute)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "deviates from block time")
+	}
+	assert.NoError(t, valSet.VerifyCommitWithTimestampTolerance(chainID, blockID, h, futureCommit, blockTime, 0))
+}
+
+func BenchmarkValidatorSet_VerifyCommit(b *testing.B) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 100, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(b, err)
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = valSet.VerifyCommit(chainID, blockID, h, commit)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = valSet.VerifyCommitParallel(chainID, blockID, h, commit, 0)
+		}
+	})
+}
+
 func TestValidatorSet_VerifyCommitLight_ReturnsAsSoonAsMajorityOfVotingPowerSigned(t *testing.T) {
 	var (
 		chainID = "test_chain_id"
@@ -770,6 +1617,27 @@ func TestValidatorSet_VerifyCommitLight_ReturnsAsSoonAsMajorityOfVotingPowerSign
 	assert.NoError(t, err)
 }
 
+func TestValidatorSet_VerifyCommitLight_AgreesWithVerifyCommit(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	for numAbsent := 0; numAbsent <= 4; numAbsent++ {
+		voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+		commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+		require.NoError(t, err)
+		for i := 0; i < numAbsent; i++ {
+			commit.Signatures[i] = NewCommitSigAbsent()
+		}
+
+		full := valSet.VerifyCommit(chainID, blockID, h, commit)
+		light := valSet.VerifyCommitLight(chainID, blockID, h, commit)
+		assert.Equal(t, full == nil, light == nil, "absent=%d full=%v light=%v", numAbsent, full, light)
+	}
+}
+
 func TestValidatorSet_VerifyCommitLightTrusting_ReturnsAsSoonAsTrustLevelOfVotingPowerSigned(t *testing.T) {
 	var (
 		chainID = "test_chain_id"
@@ -879,15 +1747,6 @@ func createNewValidatorSet(testValList []testVal) *ValidatorSet {
 	return NewValidatorSet(createNewValidatorList(testValList))
 }
 
-func valSetTotalProposerPriority(valSet *ValidatorSet) int64 {
-	sum := int64(0)
-	for _, val := range valSet.Validators {
-		// mind overflow
-		sum = safeAddClip(sum, val.ProposerPriority)
-	}
-	return sum
-}
-
 func verifyValidatorSet(t *testing.T, valSet *ValidatorSet) {
 	// verify that the capacity and length of validators is the same
 	assert.Equal(t, len(valSet.Validators), cap(valSet.Validators))
@@ -899,16 +1758,8 @@ func verifyValidatorSet(t *testing.T, valSet *ValidatorSet) {
 	assert.Equal(t, expectedTvp, tvp,
 		"expected TVP %d. Got %d, valSet=%s", expectedTvp, tvp, valSet)
 
-	// verify that validator priorities are centered
-	valsCount := int64(len(valSet.Validators))
-	tpp := valSetTotalProposerPriority(valSet)
-	assert.True(t, tpp < valsCount && tpp > -valsCount,
-		"expected total priority in (-%d, %d). Got %d", valsCount, valsCount, tpp)
-
-	// verify that priorities are scaled
-	dist := computeMaxMinPriorityDiff(valSet)
-	assert.True(t, dist <= PriorityWindowSizeFactor*tvp,
-		"expected priority distance < %d. Got %d", PriorityWindowSizeFactor*tvp, dist)
+	// verify that validator priorities are centered and scaled
+	assert.NoError(t, valSet.CheckPriorityInvariants())
 }
 
 func toTestValList(valList []*Validator) []testVal {
@@ -1006,6 +1857,135 @@ func TestValSetUpdatesDuplicateEntries(t *testing.T) {
 	}
 }
 
+func TestValidatorSetApplyValidatorUpdates(t *testing.T) {
+	pv1, pv2, pv3 := NewMockPV(), NewMockPV(), NewMockPV()
+	val1, val2, val3 := pv1.ExtractIntoValidator(10), pv2.ExtractIntoValidator(20), pv3.ExtractIntoValidator(30)
+
+	valSet := NewValidatorSet([]*Validator{val1, val2})
+	expected := valSet.Copy()
+
+	// add val3, update val1 and remove val2
+	updates := []abci.ValidatorUpdate{
+		OC2PB.ValidatorUpdate(val3),
+		OC2PB.ValidatorUpdate(NewValidator(val1.PubKey, 15)),
+		OC2PB.ValidatorUpdate(NewValidator(val2.PubKey, 0)),
+	}
+	changes, err := PB2OC.ValidatorUpdates(updates)
+	require.NoError(t, err)
+	require.NoError(t, expected.UpdateWithChangeSet(changes))
+
+	require.NoError(t, valSet.ApplyValidatorUpdates(updates))
+	assert.Equal(t, expected, valSet)
+	assert.Equal(t, 2, valSet.Size())
+	_, val := valSet.GetByAddress(val1.Address)
+	assert.EqualValues(t, 15, val.VotingPower)
+	assert.False(t, valSet.HasAddress(val2.Address))
+	assert.True(t, valSet.HasAddress(val3.Address))
+
+	// an unsupported public key type rejects the whole batch
+	before := valSet.Copy()
+	err = valSet.ApplyValidatorUpdates([]abci.ValidatorUpdate{
+		OC2PB.ValidatorUpdate(NewValidator(val2.PubKey, 20)),
+		{Power: 10},
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid validator update #1")
+		assert.Contains(t, err.Error(), "not supported")
+	}
+	assert.Equal(t, before, valSet)
+}
+
+func TestValidatorSetFromInitChain(t *testing.T) {
+	edVal := NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	secpVal := NewValidator(secp256k1.GenPrivKey().PubKey(), 20)
+
+	// mixed key types
+	abciVals := []abci.ValidatorUpdate{
+		OC2PB.ValidatorUpdate(edVal),
+		OC2PB.ValidatorUpdate(secpVal),
+	}
+	vals, err := ValidatorSetFromInitChain(abciVals)
+	require.NoError(t, err)
+	assert.Equal(t, NewValidatorSet([]*Validator{edVal, secpVal}), vals)
+	assert.NoError(t, vals.ValidateBasic())
+
+	testCases := map[string][]abci.ValidatorUpdate{
+		"no validators":        nil,
+		"unsupported key type": {OC2PB.ValidatorUpdate(edVal), {Power: 10}},
+		"zero power":           {OC2PB.ValidatorUpdate(NewValidator(edVal.PubKey, 0))},
+		"negative power":       {OC2PB.ValidatorUpdate(NewValidator(edVal.PubKey, -1))},
+		"duplicate":            {OC2PB.ValidatorUpdate(edVal), OC2PB.ValidatorUpdate(edVal)},
+	}
+	for name, abciVals := range testCases {
+		vals, err := ValidatorSetFromInitChain(abciVals)
+		assert.Error(t, err, name)
+		assert.Nil(t, vals, name)
+	}
+}
+
+func TestNextValidatorSetHash(t *testing.T) {
+	pv1, pv2, pv3 := NewMockPV(), NewMockPV(), NewMockPV()
+	val1, val2, val3 := pv1.ExtractIntoValidator(10), pv2.ExtractIntoValidator(20), pv3.ExtractIntoValidator(30)
+
+	valSet := NewValidatorSet([]*Validator{val1, val2})
+	before := valSet.Copy()
+
+	updates := []abci.ValidatorUpdate{
+		OC2PB.ValidatorUpdate(val3),
+		OC2PB.ValidatorUpdate(NewValidator(val1.PubKey, 15)),
+		OC2PB.ValidatorUpdate(NewValidator(val2.PubKey, 0)),
+	}
+	hash, err := NextValidatorSetHash(valSet, updates)
+	require.NoError(t, err)
+	assert.Equal(t, before, valSet)
+
+	changes, err := PB2OC.ValidatorUpdates(updates)
+	require.NoError(t, err)
+	expected := valSet.Copy()
+	require.NoError(t, expected.UpdateWithChangeSet(changes))
+	assert.Equal(t, expected.Hash(), hash)
+	assert.NotEqual(t, valSet.Hash(), hash)
+
+	// no updates, same hash
+	hash, err = NextValidatorSetHash(valSet, nil)
+	require.NoError(t, err)
+	assert.Equal(t, valSet.Hash(), hash)
+
+	// removing every validator is an error
+	_, err = NextValidatorSetHash(valSet, []abci.ValidatorUpdate{
+		OC2PB.ValidatorUpdate(NewValidator(val1.PubKey, 0)),
+		OC2PB.ValidatorUpdate(NewValidator(val2.PubKey, 0)),
+	})
+	assert.Error(t, err)
+	assert.Equal(t, before, valSet)
+}
+
+func TestValidatorSetUpdateWithChangeSetMaxSize(t *testing.T) {
+	valSet := createNewValidatorSet([]testVal{{"v1", 10}, {"v2", 10}, {"v3", 10}})
+	before := valSet.Copy()
+
+	// adding a 4th and 5th validator exceeds the cap of 4
+	err := valSet.UpdateWithChangeSetMaxSize(createNewValidatorList([]testVal{{"v4", 10}, {"v5", 10}}), 4)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "more than the max 4")
+	}
+	assert.Equal(t, before, valSet)
+
+	// unless a validator is removed at the same time
+	err = valSet.UpdateWithChangeSetMaxSize(createNewValidatorList([]testVal{{"v1", 0}, {"v4", 10}, {"v5", 10}}), 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, valSet.Size())
+
+	// updating the power of existing validators doesn't change the size
+	err = valSet.UpdateWithChangeSetMaxSize(createNewValidatorList([]testVal{{"v2", 20}}), 4)
+	assert.NoError(t, err)
+
+	// no cap
+	err = valSet.UpdateWithChangeSetMaxSize(createNewValidatorList([]testVal{{"v6", 10}, {"v7", 10}}), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, valSet.Size())
+}
+
 func TestValSetUpdatesOverflows(t *testing.T) {
 	maxVP := MaxTotalVotingPower
 	testCases := []valSetErrTestCase{
@@ -1040,6 +2020,51 @@ func TestValSetUpdatesOverflows(t *testing.T) {
 	}
 }
 
+// encodeValSetChanges encodes the input of DecodeValidatorSetChanges.
+func encodeValSetChanges(startVals, updateVals []testVal) []byte {
+	data := []byte{byte(len(startVals))}
+	for _, val := range append(append([]testVal{}, startVals...), updateVals...) {
+		id, err := strconv.Atoi(strings.TrimPrefix(val.name, "v"))
+		if err != nil {
+			panic(err)
+		}
+		power := make([]byte, 8)
+		binary.BigEndian.PutUint64(power, uint64(val.power))
+		data = append(append(data, byte(id)), power...)
+	}
+	return data
+}
+
+func FuzzValidatorSetUpdateWithChangeSet(f *testing.F) {
+	maxVP := MaxTotalVotingPower
+	// the cases of TestValSetUpdatesOverflows
+	seeds := []valSetErrTestCase{
+		{testValSet(2, 10), []testVal{{"v1", math.MaxInt64}}},
+		{testValSet(2, 10), []testVal{{"v2", math.MaxInt64}}},
+		{testValSet(1, maxVP), []testVal{{"v2", math.MaxInt64}}},
+		{testValSet(1, maxVP-1), []testVal{{"v2", 5}}},
+		{testValSet(2, maxVP/3), []testVal{{"v3", maxVP / 2}}},
+		{testValSet(1, maxVP), []testVal{{"v2", maxVP}}},
+	}
+	for _, seed := range seeds {
+		data := encodeValSetChanges(seed.startVals, seed.updateVals)
+		require.Error(f, FuzzUpdateWithChangeSet(data))
+		f.Add(data)
+	}
+	f.Add(encodeValSetChanges(testValSet(3, 10), []testVal{{"v1", 0}, {"v4", 20}}))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := FuzzUpdateWithChangeSet(data); err != nil {
+			return
+		}
+		start, changes, err := DecodeValidatorSetChanges(data)
+		require.NoError(t, err)
+		valSet := NewValidatorSet(start)
+		require.NoError(t, valSet.UpdateWithChangeSet(changes))
+		assert.LessOrEqual(t, valSet.TotalVotingPower(), MaxTotalVotingPower)
+	})
+}
+
 func TestValSetUpdatesOtherErrors(t *testing.T) {
 	testCases := []valSetErrTestCase{
 		{ // update with negative voting power
@@ -1424,6 +2449,135 @@ func TestNewValidatorSetFromExistingValidators(t *testing.T) {
 	assert.Equal(t, valSet.CopyIncrementProposerPriority(3), existingValSet.CopyIncrementProposerPriority(3))
 }
 
+func TestNewValidatorSetNoPriority(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	voteSet, valSet, vals := randVoteSet(h, 0, tmproto.PrecommitType, 4, 10)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+
+	valz := make([]*Validator, valSet.Size())
+	for i, val := range valSet.Validators {
+		valz[i] = NewValidator(val.PubKey, val.VotingPower)
+	}
+	noPrioritySet := NewValidatorSetNoPriority(valz)
+	for _, val := range noPrioritySet.Validators {
+		assert.Zero(t, val.ProposerPriority)
+	}
+	assert.Equal(t, valSet.TotalVotingPower(), noPrioritySet.TotalVotingPower())
+	assert.Equal(t, valSet.Hash(), noPrioritySet.Hash())
+	assert.NoError(t, noPrioritySet.VerifyCommit(chainID, blockID, h, commit))
+	assert.NoError(t, noPrioritySet.VerifyCommitLight(chainID, blockID, h, commit))
+
+	assert.Panics(t, func() { NewValidatorSetNoPriority([]*Validator{newValidator([]byte("v1"), 0)}) })
+	assert.Panics(t, func() {
+		NewValidatorSetNoPriority([]*Validator{newValidator([]byte("v1"), 1), newValidator([]byte("v1"), 2)})
+	})
+	assert.True(t, NewValidatorSetNoPriority(nil).IsNilOrEmpty())
+}
+
+func TestNewValidatorSetWithMixedKeyTypes(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	privVals := make([]PrivValidator, 0, 6)
+	for i := 0; i < 3; i++ {
+		privVals = append(privVals,
+			NewMockPVWithParams(ed25519.GenPrivKey(), false, false),
+			NewMockPVWithParams(secp256k1.GenPrivKey(), false, false))
+	}
+	valz := make([]*Validator, len(privVals))
+	for i, pv := range privVals {
+		valz[i] = pv.(MockPV).ExtractIntoValidator(10)
+	}
+	// with equal voting power the set is ordered by address
+	sort.Sort(PrivValidatorsByAddress(privVals))
+
+	valSet := NewValidatorSet(valz)
+	require.NoError(t, valSet.ValidateBasic())
+
+	// the hash doesn't depend on the order in which the validators are given
+	hash := valSet.Hash()
+	reversed := make([]*Validator, len(valz))
+	for i, val := range valz {
+		reversed[len(valz)-1-i] = val.Copy()
+	}
+	assert.Equal(t, hash, NewValidatorSet(reversed).Hash())
+
+	// nor on a protobuf round trip
+	pb, err := valSet.ToProto()
+	require.NoError(t, err)
+	fromProto, err := ValidatorSetFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, hash, fromProto.Hash())
+
+	voteSet := NewVoteSet(chainID, h, 0, tmproto.PrecommitType, valSet)
+	commit, err := MakeCommit(blockID, h, 0, voteSet, privVals, time.Now())
+	require.NoError(t, err)
+	assert.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+
+	// each signature is checked with its own key type
+	for idx, val := range valSet.Validators {
+		malleated := *commit
+		malleated.Signatures = append([]CommitSig(nil), commit.Signatures...)
+		malleated.Signatures[idx].Signature = commit.Signatures[(idx+1)%len(valz)].Signature
+		err := valSet.VerifyCommit(chainID, blockID, h, &malleated)
+		if assert.Error(t, err, val.PubKey.Type()) {
+			assert.Contains(t, err.Error(), fmt.Sprintf("wrong signature (#%d)", idx))
+		}
+	}
+}
+
+func TestVerifyCommitWithMixedKeyTypes(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+	voteSet, valSet, privVals := randVoteSetWithKeyTypes(h, 0, tmproto.PrecommitType, 10, []string{
+		ABCIPubKeyTypeEd25519, ABCIPubKeyTypeSecp256k1, ABCIPubKeyTypeEd25519, ABCIPubKeyTypeSecp256k1,
+	})
+	keyTypes := make(map[string]int)
+	for _, val := range valSet.Validators {
+		keyTypes[val.PubKey.Type()]++
+	}
+	assert.Equal(t, map[string]int{ABCIPubKeyTypeEd25519: 2, ABCIPubKeyTypeSecp256k1: 2}, keyTypes)
+
+	commit, err := MakeCommit(blockID, h, 0, voteSet, privVals, time.Now())
+	require.NoError(t, err)
+	assert.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	assert.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+	assert.NoError(t, valSet.VerifyCommitLightTrusting(chainID, commit, tmmath.Fraction{Numerator: 1, Denominator: 3}))
+
+	// a bad signature of either key type is detected
+	for _, keyType := range []string{ABCIPubKeyTypeEd25519, ABCIPubKeyTypeSecp256k1} {
+		idx := -1
+		for i, val := range valSet.Validators {
+			if val.PubKey.Type() == keyType {
+				idx = i
+				break
+			}
+		}
+		require.GreaterOrEqual(t, idx, 0)
+		bad := *commit
+		bad.Signatures = make([]CommitSig, len(commit.Signatures))
+		copy(bad.Signatures, commit.Signatures)
+		bad.Signatures[idx].Signature = append([]byte{}, commit.Signatures[idx].Signature...)
+		bad.Signatures[idx].Signature[0] ^= 0xff
+		err := valSet.VerifyCommit(chainID, blockID, h, &bad)
+		if assert.Error(t, err, keyType) {
+			assert.Contains(t, err.Error(), "wrong signature")
+		}
+	}
+}
+
 func TestValSetUpdateOverflowRelated(t *testing.T) {
 	testCases := []testVSetCfg{
 		{
@@ -1495,6 +2649,50 @@ func TestValSetUpdateOverflowRelated(t *testing.T) {
 	}
 }
 
+func TestValidatorSet_VerifyCommitPartialTrust(t *testing.T) {
+	var (
+		chainID                = "test_chain_id"
+		blockID                = makeBlockIDRandom()
+		voteSet, valSet, privs = randVoteSet(1, 1, tmproto.PrecommitType, 6, 1)
+		commit, err            = MakeCommit(blockID, 1, 1, voteSet, privs, time.Now())
+		half                   = tmmath.Fraction{Numerator: 1, Denominator: 2}
+	)
+	require.NoError(t, err)
+	// only the first 3 validators signed
+	for i := 3; i < 6; i++ {
+		commit.Signatures[i] = NewCommitSigAbsent()
+	}
+	require.Error(t, valSet.VerifyCommitLight(chainID, blockID, 1, commit))
+	subset := func(idxs ...int) [][]byte {
+		addresses := make([][]byte, len(idxs))
+		for i, idx := range idxs {
+			addresses[i] = valSet.Validators[idx].Address
+		}
+		return addresses
+	}
+
+	// 2 of the 3 trusted validators signed
+	err = valSet.VerifyCommitPartialTrust(chainID, blockID, 1, commit, subset(0, 1, 3), half)
+	assert.NoError(t, err)
+
+	// 1 of the 3 trusted validators signed
+	err = valSet.VerifyCommitPartialTrust(chainID, blockID, 1, commit, subset(0, 3, 4), half)
+	assert.Equal(t, ErrNotEnoughVotingPowerSigned{Got: 1, Needed: 1}, err)
+
+	// no trusted validator in the set
+	err = valSet.VerifyCommitPartialTrust(chainID, blockID, 1, commit, [][]byte{[]byte("unknown")}, half)
+	assert.Error(t, err)
+
+	// the commit must be for the block
+	err = valSet.VerifyCommitPartialTrust(chainID, makeBlockIDRandom(), 1, commit, subset(0, 1, 3), half)
+	assert.Error(t, err)
+
+	// a wrong signature of a trusted validator
+	commit.Signatures[1].Signature = commit.Signatures[2].Signature
+	err = valSet.VerifyCommitPartialTrust(chainID, blockID, 1, commit, subset(0, 1, 3), half)
+	assert.Error(t, err)
+}
+
 func TestValidatorSet_VerifyCommitLightTrusting(t *testing.T) {
 	var (
 		blockID                       = makeBlockIDRandom()
@@ -1611,6 +2809,67 @@ func TestValidatorSetProtoBuf(t *testing.T) {
 	}
 }
 
+func TestValidatorSetMerkleLeaves(t *testing.T) {
+	valSet, _ := RandValidatorSet(10, 10)
+	leaves := valSet.MerkleLeaves()
+	require.Len(t, leaves, 10)
+	for i, val := range valSet.Validators {
+		assert.Equal(t, val.Bytes(), leaves[i])
+	}
+	assert.Equal(t, valSet.Hash(), merkle.HashFromByteSlices(leaves))
+
+	assert.Empty(t, (&ValidatorSet{}).MerkleLeaves())
+	assert.Equal(t, (&ValidatorSet{}).Hash(), merkle.HashFromByteSlices(nil))
+}
+
+func TestValidatorSetCheckPubKeys(t *testing.T) {
+	valset, _ := RandValidatorSet(10, 100)
+	assert.NoError(t, valset.CheckPubKeys())
+
+	addr := valset.Validators[4].Address
+	valset.Validators[4].PubKey = nil
+	err := valset.CheckPubKeys()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "validator #4")
+		assert.Contains(t, err.Error(), addr.String())
+	}
+
+	// ToProto reports the same error
+	_, err2 := valset.ToProto()
+	assert.Equal(t, err, err2)
+}
+
+func TestValidatorSetFreeze(t *testing.T) {
+	valSet, _ := RandValidatorSet(4, 10)
+	assert.False(t, valSet.IsFrozen())
+
+	frozen := valSet.Freeze()
+	assert.True(t, frozen == valSet)
+	assert.True(t, valSet.IsFrozen())
+	before := valSet.Copy()
+
+	assert.PanicsWithValue(t, "cannot mutate a frozen validator set", func() {
+		_ = valSet.UpdateWithChangeSet([]*Validator{newValidator([]byte("v"), 10)})
+	})
+	assert.Panics(t, func() { valSet.IncrementProposerPriority(1) })
+	assert.Panics(t, func() { valSet.RescalePriorities(1) })
+	assert.Equal(t, before.Validators, valSet.Validators)
+
+	// read-only methods still work
+	assert.NotEmpty(t, valSet.Hash())
+	assert.EqualValues(t, 40, valSet.TotalVotingPower())
+
+	// a copy is mutable
+	valCopy := valSet.Copy()
+	assert.False(t, valCopy.IsFrozen())
+	assert.NotPanics(t, func() {
+		require.NoError(t, valCopy.UpdateWithChangeSet([]*Validator{newValidator([]byte("v"), 10)}))
+		valCopy.IncrementProposerPriority(1)
+	})
+	assert.Equal(t, 5, valCopy.Size())
+	assert.Equal(t, 4, valSet.Size())
+}
+
 func TestDividePoint(t *testing.T) {
 	assert.Equal(t, uint64(0), dividePoint(0, 0))
 	assert.Equal(t, uint64(0), dividePoint(math.MaxUint64, 0))
@@ -1693,3 +2952,90 @@ func BenchmarkUpdates(b *testing.B) {
 		assert.NoError(b, valSetCopy.UpdateWithChangeSet(newValList))
 	}
 }
+
+func TestValidateOCConsensusParamsProposerSelectionPrecision(t *testing.T) {
+	for _, bits := range []uint32{1, 64, 100, MaxProposerSelectionPrecision + 63} {
+		params := OCConsensusParams{ProposerSelectionPrecision: bits}
+		assert.Error(t, ValidateOCConsensusParams(params), bits)
+	}
+	for _, bits := range []uint32{0, 63, 126, 189, MaxProposerSelectionPrecision} {
+		params := OCConsensusParams{ProposerSelectionPrecision: bits}
+		assert.NoError(t, ValidateOCConsensusParams(params), bits)
+	}
+}
+
+func TestSelectProposerWithParams(t *testing.T) {
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), MaxTotalVotingPower/3),
+		newValidator([]byte("b"), MaxTotalVotingPower/3),
+		newValidator([]byte("c"), MaxTotalVotingPower/3),
+	})
+
+	defaults := OCConsensusParams{}
+	explicit := OCConsensusParams{ProposerSelectionPrecision: DefaultProposerSelectionPrecision}
+	precise := OCConsensusParams{ProposerSelectionPrecision: 2 * 63}
+
+	for height := int64(1); height <= 100; height++ {
+		// the default params select the same proposers as SelectProposer
+		proposer := vals.SelectProposer([]byte("hash"), height, 0)
+		assert.Equal(t, proposer, vals.SelectProposerWithParams(defaults, []byte("hash"), height, 0))
+		assert.Equal(t, proposer, vals.SelectProposerWithParams(explicit, []byte("hash"), height, 0))
+
+		// a higher precision samples the point with more random bits
+		seed := hashToSeed(MakeRoundHash([]byte("hash"), height, 0))
+		threshold := dividePointPrecise(nextRandom(&seed), &seed, vals.TotalVotingPower(), 2*63)
+		expected := vals.Validators[threshold/uint64(vals.Validators[0].VotingPower)]
+		assert.Equal(t, expected, vals.SelectProposerWithParams(precise, []byte("hash"), height, 0))
+	}
+}
+
+func TestProposerSelectionPrecisionBias(t *testing.T) {
+	// the exact share of the random numbers of the given bits electing a
+	// threshold in [lo, hi) of the total voting power
+	share := func(lo, hi, total int64, bits uint) *big.Rat {
+		points := new(big.Int).Lsh(big.NewInt(1), bits)
+		// the first random number whose threshold is at least v
+		first := func(v int64) *big.Int {
+			n := new(big.Int).Mul(big.NewInt(v), points)
+			n.Add(n, big.NewInt(total-1))
+			return n.Div(n, big.NewInt(total))
+		}
+		count := new(big.Int).Sub(first(hi), first(lo))
+		return new(big.Rat).SetFrac(count, points)
+	}
+	bias := func(lo, hi, total int64, bits uint) float64 {
+		expected := new(big.Rat).SetFrac64(hi-lo, total)
+		diff := new(big.Rat).Sub(share(lo, hi, total, bits), expected)
+		bias, _ := diff.Quo(diff, expected).Abs(diff).Float64()
+		return bias
+	}
+
+	// a validator with the least voting power among a huge total voting power
+	// 2^63/total is about 10.5: each validator with a voting power of 1 is
+	// elected by either 10 or 11 random numbers of 63 bits
+	total := MaxTotalVotingPower / 21 * 16
+	lo, hi := total/3, total/3+1
+	assert.Greater(t, bias(lo, hi, total, DefaultProposerSelectionPrecision), 0.01)
+	assert.Less(t, bias(lo, hi, total, 2*63), 1e-9)
+	assert.Less(t, bias(lo, hi, total, MaxProposerSelectionPrecision), bias(lo, hi, total, 2*63))
+}
+
+func TestProposerSelectionPrecisionWinningRate(t *testing.T) {
+	params := OCConsensusParams{ProposerSelectionPrecision: 2 * 63}
+
+	// a higher precision still elects with the same weights
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 1),
+		newValidator([]byte("b"), 100),
+		newValidator([]byte("c"), 1000),
+		newValidator([]byte("d"), 100000),
+	})
+	verifyWinningRateWithParams(t, vals, params, 200000, 0.3)
+
+	vals = NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), MaxTotalVotingPower/8),
+		newValidator([]byte("b"), MaxTotalVotingPower/4),
+		newValidator([]byte("c"), MaxTotalVotingPower/2),
+	})
+	verifyWinningRateWithParams(t, vals, params, 100000, 0.05)
+}
@@ -510,6 +510,20 @@ func randVoteSet(
 	return NewVoteSet("test_chain_id", height, round, signedMsgType, valSet), valSet, privValidators
 }
 
+// randVoteSetWithKeyTypes is the same as randVoteSet, but with a validator of
+// each given key type.
+// NOTE: privValidators are in order
+func randVoteSetWithKeyTypes(
+	height int64,
+	round int32,
+	signedMsgType tmproto.SignedMsgType,
+	votingPower int64,
+	keyTypes []string,
+) (*VoteSet, *ValidatorSet, []PrivValidator) {
+	valSet, privValidators := RandValidatorSetWithKeyTypes(votingPower, keyTypes)
+	return NewVoteSet("test_chain_id", height, round, signedMsgType, valSet), valSet, privValidators
+}
+
 func addVoteByAllValidatorSet(t *testing.T, voteSet *VoteSet, privVals []PrivValidator, height int64, round int32) []Vote {
 	votes := make([]Vote, voteSet.Size())
 	for i, val := range voteSet.valSet.Validators {